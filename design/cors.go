@@ -0,0 +1,27 @@
+package design
+
+import "regexp"
+
+// CORSDefinition describes the cross-origin resource sharing policy for a single origin, as built
+// up by the CORS DSL.
+type CORSDefinition struct {
+	Origin           string
+	Regexp           *regexp.Regexp
+	Methods          []string
+	Headers          []string
+	Exposed          []string
+	AllowCredentials bool
+	MaxAge           int
+	Vary             []string
+}
+
+// MatchesOrigin reports whether the policy applies to the given request Origin header value.
+func (c *CORSDefinition) MatchesOrigin(origin string) bool {
+	if c.Origin == "*" {
+		return true
+	}
+	if c.Regexp != nil {
+		return c.Regexp.MatchString(origin)
+	}
+	return c.Origin == origin
+}
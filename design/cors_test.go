@@ -0,0 +1,26 @@
+package design
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestCORSDefinition_MatchesOrigin(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy *CORSDefinition
+		origin string
+		want   bool
+	}{
+		{"wildcard matches anything", &CORSDefinition{Origin: "*"}, "https://goa.design", true},
+		{"exact match", &CORSDefinition{Origin: "https://goa.design"}, "https://goa.design", true},
+		{"exact mismatch", &CORSDefinition{Origin: "https://goa.design"}, "https://evil.com", false},
+		{"regexp match", &CORSDefinition{Regexp: regexp.MustCompile(`goa\.design$`)}, "https://swagger.goa.design", true},
+		{"regexp mismatch", &CORSDefinition{Regexp: regexp.MustCompile(`goa\.design$`)}, "https://evil.com", false},
+	}
+	for _, c := range cases {
+		if got := c.policy.MatchesOrigin(c.origin); got != c.want {
+			t.Errorf("%s: MatchesOrigin(%q) = %v, want %v", c.name, c.origin, got, c.want)
+		}
+	}
+}
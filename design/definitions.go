@@ -0,0 +1,239 @@
+// Package design defines the in-memory representation of a goa API design as built up by the
+// functions in design/dsl. The dsl package evaluates the user's design DSL and populates Design,
+// the single APIDefinition instance describing the whole API; goagen generators then walk Design
+// to produce code, and in this chunk the Swagger generator in goagen/gen_swagger.
+package design
+
+import (
+	"strings"
+)
+
+// Definition is implemented by every node the DSL can push onto the evaluation context stack
+// (APIDefinition, ResourceDefinition, ActionDefinition, AttributeDefinition, ...).
+type Definition interface{}
+
+// DataStructure is implemented by types that describe a data structure, i.e. that can return the
+// corresponding attribute. UserTypeDefinition and MediaTypeDefinition both implement it so that
+// DSL functions like Payload can accept either.
+type DataStructure interface {
+	Definition() *AttributeDefinition
+}
+
+// DataType is the interface implemented by goa's built-in primitive and Object types as
+// well as by user types (via UserTypeDefinition.Type). ToJSONSchema returns the JSON schema
+// document describing the type, used by goagen's Swagger generator.
+type DataType interface {
+	Kind() Kind
+	ToJSONSchema() interface{}
+}
+
+// Kind identifies the category of a DataType.
+type Kind int
+
+// Supported data type kinds.
+const (
+	BooleanKind Kind = iota + 1
+	IntegerKind
+	NumberKind
+	StringKind
+	ArrayKind
+	ObjectKind
+)
+
+// Primitive is a DataType for goa's built-in boolean, integer, number and string types.
+type Primitive Kind
+
+// Kind implements DataType.
+func (p Primitive) Kind() Kind { return Kind(p) }
+
+// Built-in primitive types, referenced from the design DSL (e.g. Param("id", Integer)).
+var (
+	Boolean = Primitive(BooleanKind)
+	Integer = Primitive(IntegerKind)
+	Number  = Primitive(NumberKind)
+	String  = Primitive(StringKind)
+)
+
+// Object is the DataType of an attribute that has named children, e.g. a Payload or MediaType.
+type Object map[string]*AttributeDefinition
+
+// Kind implements DataType.
+func (o Object) Kind() Kind { return ObjectKind }
+
+// AttributeDefinition describes a single attribute, e.g. a Payload, a Param, a Header or a
+// MediaType's member. Type is set once the DSL describing the attribute's members (if any) has
+// run; Reference points to a parent attribute (such as a resource's default media type) used to
+// inherit member definitions that are not overridden locally.
+type AttributeDefinition struct {
+	Type        DataType
+	Reference   DataType
+	Description string
+	Required    []string
+}
+
+// IsRequired returns true if name is listed in the attribute's Required list.
+func (a *AttributeDefinition) IsRequired(name string) bool {
+	for _, r := range a.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToObject returns the attribute's Type as an Object, or nil if the attribute does not describe
+// an object (e.g. it has no sub-definitions).
+func (a *AttributeDefinition) ToObject() Object {
+	o, _ := a.Type.(Object)
+	return o
+}
+
+// UserTypeDefinition is a user-defined type, e.g. a Payload or a type declared via Type().
+type UserTypeDefinition struct {
+	*AttributeDefinition
+	TypeName string
+}
+
+// Definition implements DataStructure.
+func (u *UserTypeDefinition) Definition() *AttributeDefinition { return u.AttributeDefinition }
+
+// MediaTypeDefinition is a user-defined media type, i.e. a UserTypeDefinition with an identifier.
+type MediaTypeDefinition struct {
+	*UserTypeDefinition
+	Identifier string
+}
+
+// RouteDefinition describes a single route of an Action, as created by GET, POST, WS, etc.
+type RouteDefinition struct {
+	Verb   string
+	Path   string
+	Parent *ActionDefinition
+}
+
+// ResponseDefinition describes a single response an Action may return.
+type ResponseDefinition struct {
+	Name        string
+	Status      int
+	Description string
+	MediaType   string
+	Headers     *AttributeDefinition
+	// Parent is the ActionDefinition or ResourceDefinition the response was defined on; a
+	// response defined on a Resource applies to every action of the resource that references
+	// it by name.
+	Parent Definition
+}
+
+// ActionDefinition describes a single API endpoint.
+type ActionDefinition struct {
+	Name        string
+	Description string
+	Parent      *ResourceDefinition
+	Routes      []*RouteDefinition
+	Headers     *AttributeDefinition
+	Params      *AttributeDefinition
+	Payload     *UserTypeDefinition
+	Responses   []*ResponseDefinition
+
+	CORS       []*CORSDefinition
+	Docs       *DocsDefinition
+	Security   *SecurityRequirementDefinition
+	NoSecurity bool
+	Consumes   []*EncodingDefinition
+	Produces   []*EncodingDefinition
+	Middleware []*MiddlewareRef
+	Skipped    []string
+	Stream     *StreamDefinition
+}
+
+// ResourceDefinition describes a group of related actions sharing a base path and default media
+// type.
+type ResourceDefinition struct {
+	Name        string
+	Description string
+	BasePath    string
+	MediaType   string
+	Actions     map[string]*ActionDefinition
+	Headers   *AttributeDefinition
+	Params    *AttributeDefinition
+
+	CORS       []*CORSDefinition
+	Docs       *DocsDefinition
+	Security   *SecurityRequirementDefinition
+	Consumes   []*EncodingDefinition
+	Produces   []*EncodingDefinition
+	Middleware []*MiddlewareRef
+}
+
+// FullPath returns the resource's base path.
+func (r *ResourceDefinition) FullPath() string { return r.BasePath }
+
+// IterateActions calls it with every action of the resource, stopping and returning the first
+// error encountered, if any.
+func (r *ResourceDefinition) IterateActions(it func(*ActionDefinition) error) error {
+	for _, a := range r.Actions {
+		if err := it(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// APIDefinition is the root of a goa design, populated by the top-level DSL functions (API,
+// Resource, MediaType, Type, ...). Design is the single instance being built while the DSL
+// executes.
+type APIDefinition struct {
+	Title       string
+	Description string
+	Types       map[string]*UserTypeDefinition
+	MediaTypes  map[string]*MediaTypeDefinition
+	Resources   map[string]*ResourceDefinition
+	Errors      []error
+
+	CORS            []*CORSDefinition
+	Docs            *DocsDefinition
+	SwaggerPath     string
+	SecuritySchemes map[string]*SecuritySchemeDefinition
+	Security        *SecurityRequirementDefinition
+	Consumes        []*EncodingDefinition
+	Produces        []*EncodingDefinition
+	Middleware      []*MiddlewareRef
+}
+
+// IterateResources calls it with every resource of the API, stopping and returning the first
+// error encountered, if any.
+func (a *APIDefinition) IterateResources(it func(*ResourceDefinition) error) error {
+	for _, r := range a.Resources {
+		if err := it(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Design is the API definition being built by the currently executing DSL.
+var Design = &APIDefinition{
+	Types:      make(map[string]*UserTypeDefinition),
+	MediaTypes: make(map[string]*MediaTypeDefinition),
+	Resources:  make(map[string]*ResourceDefinition),
+}
+
+// ExtractWildcards returns the names of the httprouter ":name" and "*name" wildcards found in
+// path, in order.
+func ExtractWildcards(path string) []string {
+	var wcs []string
+	for _, seg := range strings.Split(path, "/") {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			wcs = append(wcs, seg[1:])
+		}
+	}
+	return wcs
+}
+
+// Error implements the error interface by concatenating every recorded design error.
+func (a *APIDefinition) Error() string {
+	msgs := make([]string, len(a.Errors))
+	for i, e := range a.Errors {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
@@ -0,0 +1,8 @@
+package design
+
+// DocsDefinition records supplementary documentation metadata set via the Docs DSL, consumed by
+// documentation generators such as goagen's Swagger generator.
+type DocsDefinition struct {
+	Description string
+	URL         string
+}
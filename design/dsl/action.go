@@ -75,9 +75,55 @@ func Routing(routes ...*RouteDefinition) {
 					}
 				}
 			}
+			if r.Verb == "WS" && hasCatchAllWildcard(r.Path) {
+				ReportError(`WS route "%s" may not use a catch-all wildcard`, r.Path)
+			}
 			r.Parent = a
 			a.Routes = append(a.Routes, r)
 		}
+		validateRouteVerbs(a)
+	}
+}
+
+// Well-known response names usable with Response, each backed by the ResponseDefinition of the
+// same name in design.ResponseTemplates.
+const (
+	OK           = "OK"
+	Created      = "Created"
+	NoContent    = "NoContent"
+	BadRequest   = "BadRequest"
+	Unauthorized = "Unauthorized"
+	Forbidden    = "Forbidden"
+	NotFound     = "NotFound"
+)
+
+// Response describes a single HTTP response an Action may return, identified by name - either one
+// of the well-known names above or one added to design.ResponseTemplates by the application. The
+// optional DSL overrides the template's Description or Headers. It must appear inside
+// Action:
+//
+//	Response(NoContent)
+//	Response(NotFound, func() {
+//		Description("Account not found")
+//	})
+func Response(name string, dsl ...func()) {
+	if len(dsl) > 1 {
+		ReportError("too many arguments given to Response")
+		return
+	}
+	tmpl, ok := ResponseTemplates[name]
+	if !ok {
+		ReportError("Response: unknown response template %q", name)
+		return
+	}
+	if a, ok := actionDefinition(true); ok {
+		resp := &ResponseDefinition{Name: tmpl.Name, Status: tmpl.Status, MediaType: tmpl.MediaType, Parent: a}
+		if len(dsl) == 1 {
+			if !executeDSL(dsl[0], resp) {
+				return
+			}
+		}
+		a.Responses = append(a.Responses, resp)
 	}
 }
 
@@ -0,0 +1,114 @@
+package dsl
+
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/raphael/goa/design"
+)
+
+// CORS implements the CORS definition DSL. CORS describes the cross-origin resource sharing
+// policy that applies to an API, a resource or a specific action. The origin argument may be an
+// exact match (e.g. "https://goa.design"), the wildcard "*" or a regular expression wrapped in
+// slashes (e.g. "/goa\\.design$/"). CORS defined on Resource or Action overrides the policy
+// inherited from the enclosing definition for the given origin; CORS defined on API establishes
+// the default policy applied to every resource and action that does not define its own. Here is
+// an example showing the most common sub-definitions:
+//
+//	CORS("https://swagger.goa.design", func() {
+//		Methods("GET", "POST")          // Allowed methods, defaults to the union of Routing verbs
+//		AllowHeaders("X-Shared-Secret") // Request headers the browser may send
+//		ExposeHeaders("X-Total-Count")  // Response headers exposed to the client
+//		MaxAge(600)                     // How long the preflight response may be cached
+//		Credentials()                   // Allow cookies and HTTP auth to be sent
+//	})
+//
+// CORS may be called multiple times on the same API, Resource or Action in which case each call
+// defines the policy for an additional origin.
+func CORS(origin string, dsl func()) {
+	cors := &CORSDefinition{Origin: origin}
+	if strings.HasPrefix(origin, "/") && strings.HasSuffix(origin, "/") && len(origin) > 1 {
+		exp := origin[1 : len(origin)-1]
+		re, err := regexp.Compile(exp)
+		if err != nil {
+			ReportError("invalid CORS origin regexp %#v: %s", origin, err)
+			return
+		}
+		cors.Regexp = re
+	}
+	if !executeDSL(dsl, cors) {
+		return
+	}
+	if cors.AllowCredentials && (cors.Origin == "*" || cors.Regexp != nil) {
+		ReportError("CORS: AllowCredentials cannot be used with a wildcard or regular expression origin")
+		return
+	}
+	if a, ok := actionDefinition(false); ok {
+		a.CORS = append(a.CORS, cors)
+	} else if r, ok := resourceDefinition(false); ok {
+		r.CORS = append(r.CORS, cors)
+	} else if api, ok := apiDefinition(true); ok {
+		api.CORS = append(api.CORS, cors)
+	}
+}
+
+// Methods lists the HTTP methods allowed by a CORS policy. It must appear inside a CORS
+// definition. When not set the generator defaults to the union of the verbs used by the Routing
+// definitions of the actions the policy applies to.
+func Methods(methods ...string) {
+	if c, ok := corsDefinition(true); ok {
+		c.Methods = append(c.Methods, methods...)
+	}
+}
+
+// AllowHeaders lists the request headers the browser is allowed to send as part of the actual
+// (non preflight) CORS request. It must appear inside a CORS definition.
+func AllowHeaders(headers ...string) {
+	if c, ok := corsDefinition(true); ok {
+		c.Headers = append(c.Headers, headers...)
+	}
+}
+
+// ExposeHeaders lists the response headers exposed to the browser via the
+// Access-Control-Expose-Headers header. It must appear inside a CORS definition.
+func ExposeHeaders(headers ...string) {
+	if c, ok := corsDefinition(true); ok {
+		c.Exposed = append(c.Exposed, headers...)
+	}
+}
+
+// Credentials indicates that the browser may send cookies and HTTP authentication along with the
+// request and allows the client to read the response when its credentials mode is "include". It
+// must appear inside a CORS definition and may not be combined with a wildcard or regular
+// expression Origin.
+func Credentials() {
+	if c, ok := corsDefinition(true); ok {
+		c.AllowCredentials = true
+	}
+}
+
+// MaxAge sets the number of seconds a preflight response may be cached by the browser. It must
+// appear inside a CORS definition.
+func MaxAge(seconds int) {
+	if c, ok := corsDefinition(true); ok {
+		c.MaxAge = seconds
+	}
+}
+
+// Vary lists additional header names appended to the generated response's Vary header. Origin is
+// always included and does not need to be repeated. It must appear inside a CORS definition.
+func Vary(headers ...string) {
+	if c, ok := corsDefinition(true); ok {
+		c.Vary = append(c.Vary, headers...)
+	}
+}
+
+// corsDefinition returns the CORS definition at the top of the DSL context stack, reporting an
+// error via ReportError if required is true and the context does not contain one.
+func corsDefinition(required bool) (*CORSDefinition, bool) {
+	c, ok := ctxStack.current().(*CORSDefinition)
+	if !ok && required {
+		ReportError("not inside a CORS definition")
+	}
+	return c, ok
+}
@@ -0,0 +1,68 @@
+package dsl
+
+import (
+	"testing"
+
+	. "github.com/raphael/goa/design"
+)
+
+// withContext pushes def onto ctxStack for the duration of fn, mimicking what executeDSL does
+// for the definitions Action/Resource/API normally push themselves.
+func withContext(def Definition, fn func()) {
+	ctxStack = append(ctxStack, def)
+	fn()
+	ctxStack = ctxStack[:len(ctxStack)-1]
+}
+
+func withFreshDesign(fn func()) {
+	orig := Design
+	Design = &APIDefinition{}
+	defer func() { Design = orig }()
+	fn()
+}
+
+func TestCORS_ScopesToEnclosingDefinition(t *testing.T) {
+	withFreshDesign(func() {
+		CORS("https://goa.design", func() {})
+		if len(Design.CORS) != 1 || Design.CORS[0].Origin != "https://goa.design" {
+			t.Fatalf("API-scoped CORS not recorded: %+v", Design.CORS)
+		}
+
+		res := &ResourceDefinition{}
+		withContext(res, func() {
+			CORS("https://example.com", func() { Methods("GET") })
+		})
+		if len(res.CORS) != 1 || len(res.CORS[0].Methods) != 1 || res.CORS[0].Methods[0] != "GET" {
+			t.Fatalf("Resource-scoped CORS not recorded: %+v", res.CORS)
+		}
+
+		act := &ActionDefinition{Parent: res}
+		withContext(act, func() {
+			CORS("https://api.goa.design", func() { Credentials() })
+		})
+		if len(act.CORS) != 1 || !act.CORS[0].AllowCredentials {
+			t.Fatalf("Action-scoped CORS not recorded: %+v", act.CORS)
+		}
+	})
+}
+
+func TestCORS_RejectsCredentialsWithWildcardOrigin(t *testing.T) {
+	withFreshDesign(func() {
+		CORS("*", func() { Credentials() })
+		if len(Design.Errors) == 0 {
+			t.Fatal("expected an error combining Credentials with a wildcard origin")
+		}
+	})
+}
+
+func TestCORS_CompilesRegexpOrigin(t *testing.T) {
+	withFreshDesign(func() {
+		CORS(`/goa\.design$/`, func() {})
+		if len(Design.Errors) != 0 {
+			t.Fatalf("unexpected errors: %v", Design.Errors)
+		}
+		if Design.CORS[0].Regexp == nil {
+			t.Fatal("expected Regexp to be compiled from the /.../ origin")
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package dsl
+
+import (
+	. "github.com/raphael/goa/design"
+)
+
+// Docs records documentation metadata on an API, Resource or Action definition. The information
+// is not used by the HTTP generators but is picked up by documentation generators such as
+// goagen's swagger generator to produce a more useful specification. Here is an example:
+//
+//	Docs(func() {
+//		Description("Additional information about this action")
+//		ExternalDocs("http://goa.design/docs/actions")
+//	})
+func Docs(dsl func()) {
+	docs := new(DocsDefinition)
+	if !executeDSL(dsl, docs) {
+		return
+	}
+	if a, ok := actionDefinition(false); ok {
+		a.Docs = docs
+	} else if r, ok := resourceDefinition(false); ok {
+		r.Docs = docs
+	} else if api, ok := apiDefinition(true); ok {
+		api.Docs = docs
+	}
+}
+
+// ExternalDocs sets a URL - and optional description - that points to supplementary
+// documentation. It must appear inside a Docs definition.
+func ExternalDocs(url string, description ...string) {
+	if d, ok := docsDefinition(true); ok {
+		d.URL = url
+		if len(description) > 0 {
+			d.Description = description[0]
+		}
+		if len(description) > 1 {
+			ReportError("ExternalDocs: too many arguments, accepts at most a URL and a description")
+		}
+	}
+}
+
+// Description sets the description of the enclosing API, Resource, Action, Response, Docs or
+// security scheme definition.
+func Description(description string) {
+	switch def := ctxStack.current().(type) {
+	case *APIDefinition:
+		def.Description = description
+	case *ResourceDefinition:
+		def.Description = description
+	case *ActionDefinition:
+		def.Description = description
+	case *ResponseDefinition:
+		def.Description = description
+	case *DocsDefinition:
+		def.Description = description
+	case *SecuritySchemeDefinition:
+		def.Description = description
+	default:
+		ReportError("Description: not inside a definition that accepts a description")
+	}
+}
+
+// Swagger sets the mount path of the OpenAPI/Swagger specification generated by goagen's
+// gen_swagger package. It must appear inside API. The path is recorded in the API definition's
+// SwaggerPath field only: no handler serving the document is installed here, and no default path
+// is applied, so SwaggerPath stays empty when Swagger is not used.
+func Swagger(path string) {
+	if api, ok := apiDefinition(true); ok {
+		api.SwaggerPath = path
+	}
+}
+
+// docsDefinition returns the Docs definition at the top of the DSL context stack, reporting an
+// error via ReportError if required is true and the context does not contain one.
+func docsDefinition(required bool) (*DocsDefinition, bool) {
+	d, ok := ctxStack.current().(*DocsDefinition)
+	if !ok && required {
+		ReportError("not inside a Docs definition")
+	}
+	return d, ok
+}
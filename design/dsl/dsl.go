@@ -0,0 +1,83 @@
+package dsl
+
+import (
+	"fmt"
+
+	. "github.com/raphael/goa/design"
+)
+
+// contextStack is the stack of definitions being filled in by the DSL currently executing, the
+// innermost (most recently entered) scope last.
+type contextStack []Definition
+
+// current returns the innermost definition being evaluated, or nil if the stack is empty.
+func (s contextStack) current() Definition {
+	if len(s) == 0 {
+		return nil
+	}
+	return s[len(s)-1]
+}
+
+// ctxStack tracks the nesting of DSL scopes (API, Resource, Action, CORS, ...) so that helpers
+// like resourceDefinition and actionDefinition can recover the definition the DSL function they
+// back was called from.
+var ctxStack contextStack
+
+// executeDSL pushes def onto ctxStack, runs dsl and pops def back off, returning false if dsl
+// reported any error via ReportError while it ran.
+func executeDSL(dsl func(), def Definition) bool {
+	if dsl == nil {
+		return true
+	}
+	ctxStack = append(ctxStack, def)
+	defer func() { ctxStack = ctxStack[:len(ctxStack)-1] }()
+	before := len(Design.Errors)
+	dsl()
+	return len(Design.Errors) == before
+}
+
+// ReportError records a DSL usage error against the design being built. Errors accumulate in
+// Design.Errors so that the whole design can be validated in one pass once the DSL has finished
+// running.
+func ReportError(format string, vals ...interface{}) {
+	Design.Errors = append(Design.Errors, fmt.Errorf(format, vals...))
+}
+
+// resourceDefinition returns the ResourceDefinition at the top of the DSL context stack,
+// reporting an error via ReportError if required is true and the context does not contain one.
+func resourceDefinition(required bool) (*ResourceDefinition, bool) {
+	r, ok := ctxStack.current().(*ResourceDefinition)
+	if !ok && required {
+		ReportError("not inside a Resource definition")
+	}
+	return r, ok
+}
+
+// actionDefinition returns the ActionDefinition at the top of the DSL context stack, reporting an
+// error via ReportError if required is true and the context does not contain one.
+func actionDefinition(required bool) (*ActionDefinition, bool) {
+	a, ok := ctxStack.current().(*ActionDefinition)
+	if !ok && required {
+		ReportError("not inside an Action definition")
+	}
+	return a, ok
+}
+
+// responseDefinition returns the ResponseDefinition at the top of the DSL context stack,
+// reporting an error via ReportError if required is true and the context does not contain one.
+func responseDefinition(required bool) (*ResponseDefinition, bool) {
+	r, ok := ctxStack.current().(*ResponseDefinition)
+	if !ok && required {
+		ReportError("not inside a Response definition")
+	}
+	return r, ok
+}
+
+// apiDefinition returns Design, the single top-level API definition being built. Resource- and
+// Action-scoped DSL functions check actionDefinition/resourceDefinition first and only fall back
+// to apiDefinition to apply at the API's default scope, so required is only ever false when a
+// caller wants to probe without reporting; apiDefinition itself always succeeds since Design is
+// never nil.
+func apiDefinition(required bool) (*APIDefinition, bool) {
+	return Design, true
+}
@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	. "github.com/raphael/goa/design"
+)
+
+// Consumes registers a decoder for the given media type, used by the generated handlers to parse
+// incoming request bodies. packagePath identifies the Go package implementing the
+// goa/encoding.Decoder interface for that media type and extensions lists the file extensions
+// (without the leading dot) that also select this media type, e.g. for content sniffing or
+// documentation purposes. Consumes may be called at API scope to set the defaults, and at
+// Resource or Action scope to override them. Example:
+//
+//	Consumes("application/json", "github.com/raphael/goa/encoding/json")
+//	Consumes("application/xml", "github.com/raphael/goa/encoding/xml", "xml")
+func Consumes(mediaType string, packagePath string, extensions ...string) {
+	enc := &EncodingDefinition{MIMETypes: []string{mediaType}, PackagePath: packagePath, Extensions: extensions}
+	registerEncoding(enc, false)
+}
+
+// Produces registers an encoder for the given media type, used by the generated handlers to
+// write response bodies. See Consumes for the meaning of packagePath and extensions. Produces may
+// be called at API scope to set the defaults, and at Resource or Action scope to override them.
+// Example:
+//
+//	Produces("application/json", "github.com/raphael/goa/encoding/json")
+func Produces(mediaType string, packagePath string, extensions ...string) {
+	enc := &EncodingDefinition{MIMETypes: []string{mediaType}, PackagePath: packagePath, Extensions: extensions}
+	registerEncoding(enc, true)
+}
+
+// registerEncoding appends enc to the Produces or Consumes list of the innermost API, Resource or
+// Action definition currently being defined.
+func registerEncoding(enc *EncodingDefinition, produces bool) {
+	if a, ok := actionDefinition(false); ok {
+		if produces {
+			a.Produces = append(a.Produces, enc)
+		} else {
+			a.Consumes = append(a.Consumes, enc)
+		}
+	} else if r, ok := resourceDefinition(false); ok {
+		if produces {
+			r.Produces = append(r.Produces, enc)
+		} else {
+			r.Consumes = append(r.Consumes, enc)
+		}
+	} else if api, ok := apiDefinition(true); ok {
+		if produces {
+			api.Produces = append(api.Produces, enc)
+		} else {
+			api.Consumes = append(api.Consumes, enc)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package dsl
+
+import (
+	"fmt"
+
+	. "github.com/raphael/goa/design"
+	"github.com/raphael/goa/middleware"
+)
+
+// Use records one or more middleware to be applied to the requests handled by the enclosing API,
+// Resource or Action. Middleware declared on API applies to every resource and action, middleware
+// declared on Resource applies to every action of the resource, and both are run, in order,
+// before any middleware declared on the Action itself:
+//
+//	API("cellar", func() {
+//		Use("RequestID", "LogRequest")
+//	})
+//
+//	Resource("bottle", func() {
+//		Use("Gzip")
+//		Action("show", func() {
+//			Use("Timeout")
+//		})
+//	})
+//
+// results in the chain RequestID, LogRequest, Gzip, Timeout for resource "bottle"'s "show"
+// action. Names must either refer to a built-in middleware (RequestID, LogRequest, Recover,
+// Timeout, Gzip) or to a middleware registered by the user via middleware.Register before the
+// design DSL runs; Use reports an error at design time for any name that resolves to neither.
+func Use(names ...string) {
+	refs := make([]*MiddlewareRef, 0, len(names))
+	for _, name := range names {
+		if _, ok := middleware.Registry[name]; !ok {
+			ReportError("Use: middleware %q is not registered", name)
+			continue
+		}
+		refs = append(refs, &MiddlewareRef{Name: name})
+	}
+	if a, ok := actionDefinition(false); ok {
+		a.Middleware = append(a.Middleware, refs...)
+	} else if r, ok := resourceDefinition(false); ok {
+		r.Middleware = append(r.Middleware, refs...)
+	} else if api, ok := apiDefinition(true); ok {
+		api.Middleware = append(api.Middleware, refs...)
+	}
+}
+
+// Skip opts the enclosing Action out of a middleware inherited from its Resource or the API. It
+// must appear inside Action. Skip reports an error at design time if name does not match any
+// middleware registered via Use on the action's resource or the API.
+func Skip(name string) {
+	if a, ok := actionDefinition(true); ok {
+		found := false
+		for _, m := range a.Parent.Middleware {
+			if m.Name == name {
+				found = true
+			}
+		}
+		for _, m := range Design.Middleware {
+			if m.Name == name {
+				found = true
+			}
+		}
+		if !found {
+			ReportError(fmt.Sprintf(`Skip: middleware %q is not used by resource %q or the API`, name, a.Parent.Name))
+			return
+		}
+		a.Skipped = append(a.Skipped, name)
+	}
+}
@@ -0,0 +1,31 @@
+package dsl
+
+import (
+	"testing"
+
+	. "github.com/raphael/goa/design"
+)
+
+func TestUse_RejectsUnregisteredMiddleware(t *testing.T) {
+	withFreshDesign(func() {
+		Use("RequestID", "Recoverr")
+		if len(Design.Errors) == 0 {
+			t.Fatal("expected an error for the misspelled middleware name")
+		}
+		if len(Design.Middleware) != 1 || Design.Middleware[0].Name != "RequestID" {
+			t.Fatalf("Middleware = %+v, want only the valid RequestID entry", Design.Middleware)
+		}
+	})
+}
+
+func TestUse_AcceptsBuiltins(t *testing.T) {
+	withFreshDesign(func() {
+		Use("RequestID", "LogRequest", "Recover", "Timeout", "Gzip")
+		if len(Design.Errors) != 0 {
+			t.Fatalf("unexpected errors: %v", Design.Errors)
+		}
+		if len(Design.Middleware) != 5 {
+			t.Fatalf("got %d middleware, want 5", len(Design.Middleware))
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package dsl
+
+import (
+	. "github.com/raphael/goa/design"
+)
+
+// BasicAuthSecurity defines a security scheme using HTTP basic authentication. The scheme may
+// then be referenced by name from Security. Example:
+//
+//	BasicAuthSecurity("basic", func() {
+//		Description("Use your account credentials")
+//	})
+func BasicAuthSecurity(name string, dsl ...func()) {
+	scheme := &SecuritySchemeDefinition{SchemeName: name, Kind: BasicAuthSecurityKind, Type: "basic"}
+	finalizeSecurityScheme(scheme, dsl...)
+}
+
+// APIKeySecurity defines a security scheme that authenticates requests via an API key carried in
+// a header or query string. The SecurityHeader or SecurityQuery sub-definition indicates where
+// the generated middleware looks up the key and under what name. Example:
+//
+//	APIKeySecurity("api_key", func() {
+//		SecurityHeader("X-API-Key")
+//	})
+func APIKeySecurity(name string, dsl ...func()) {
+	scheme := &SecuritySchemeDefinition{SchemeName: name, Kind: APIKeySecurityKind, Type: "apiKey"}
+	finalizeSecurityScheme(scheme, dsl...)
+}
+
+// JWTSecurity defines a security scheme that authenticates requests using a JSON Web Token. The
+// DSL accepts a TokenURL and any number of Scope declarations describing the scopes a client may
+// request. Example:
+//
+//	JWTSecurity("jwt", func() {
+//		SecurityHeader("Authorization")
+//		TokenURL("https://example.com/token")
+//		Scope("api:read", "Read access to the API")
+//		Scope("api:write", "Write access to the API")
+//	})
+func JWTSecurity(name string, dsl ...func()) {
+	scheme := &SecuritySchemeDefinition{SchemeName: name, Kind: JWTSecurityKind, Type: "apiKey"}
+	finalizeSecurityScheme(scheme, dsl...)
+}
+
+// OAuth2Security defines a security scheme using OAuth2. The DSL describes the flow specific
+// URLs (AuthorizationURL, TokenURL) and the scopes available via Scope. Example:
+//
+//	OAuth2Security("googauth", func() {
+//		AuthorizationURL("https://accounts.google.com/o/oauth2/auth")
+//		TokenURL("https://accounts.google.com/o/oauth2/token")
+//		Scope("email", "View your email address")
+//	})
+func OAuth2Security(name string, dsl ...func()) {
+	scheme := &SecuritySchemeDefinition{SchemeName: name, Kind: OAuth2SecurityKind, Type: "oauth2"}
+	finalizeSecurityScheme(scheme, dsl...)
+}
+
+// finalizeSecurityScheme runs the (optional) scheme DSL and registers the resulting scheme on
+// the API definition under construction.
+func finalizeSecurityScheme(scheme *SecuritySchemeDefinition, dsl ...func()) {
+	if len(dsl) > 1 {
+		ReportError("too many arguments given to security scheme definition")
+		return
+	}
+	if len(dsl) == 1 {
+		if !executeDSL(dsl[0], scheme) {
+			return
+		}
+	}
+	if api, ok := apiDefinition(true); ok {
+		if api.SecuritySchemes == nil {
+			api.SecuritySchemes = make(map[string]*SecuritySchemeDefinition)
+		}
+		api.SecuritySchemes[scheme.SchemeName] = scheme
+	}
+}
+
+// SecurityHeader indicates that the enclosing security scheme expects its credentials in the
+// given HTTP request header. It must appear inside a security scheme definition (APIKeySecurity,
+// JWTSecurity). It is named distinctly from the attribute DSL's Header so the two may be used
+// without colliding: this one locates credentials for a scheme, that one describes a header's
+// type and validations.
+func SecurityHeader(name string) {
+	if s, ok := securitySchemeDefinition(true); ok {
+		s.In = "header"
+		s.Name = name
+	}
+}
+
+// SecurityQuery indicates that the enclosing security scheme expects its credentials in the given
+// query string parameter. It must appear inside a security scheme definition.
+func SecurityQuery(name string) {
+	if s, ok := securitySchemeDefinition(true); ok {
+		s.In = "query"
+		s.Name = name
+	}
+}
+
+// SecurityCookie indicates that the enclosing security scheme expects its credentials in the
+// given cookie. It must appear inside a security scheme definition.
+func SecurityCookie(name string) {
+	if s, ok := securitySchemeDefinition(true); ok {
+		s.In = "cookie"
+		s.Name = name
+	}
+}
+
+// TokenURL sets the URL clients use to retrieve a token. It must appear inside a JWTSecurity or
+// OAuth2Security definition.
+func TokenURL(url string) {
+	if s, ok := securitySchemeDefinition(true); ok {
+		s.TokenURL = url
+	}
+}
+
+// AuthorizationURL sets the URL clients are redirected to in order to authorize the application.
+// It must appear inside an OAuth2Security definition.
+func AuthorizationURL(url string) {
+	if s, ok := securitySchemeDefinition(true); ok {
+		s.AuthorizationURL = url
+	}
+}
+
+// Scope declares a scope. Inside a JWTSecurity or OAuth2Security definition it registers a scope
+// clients may request along with its human readable description. Inside a Security definition it
+// instead restricts the enclosing API, Resource or Action to requiring that scope.
+func Scope(name string, description ...string) {
+	switch def := ctxStack.current().(type) {
+	case *SecuritySchemeDefinition:
+		if def.Scopes == nil {
+			def.Scopes = make(map[string]string)
+		}
+		var desc string
+		if len(description) > 0 {
+			desc = description[0]
+		}
+		def.Scopes[name] = desc
+	case *SecurityRequirementDefinition:
+		def.Scopes = append(def.Scopes, name)
+	default:
+		ReportError("not inside a security scheme or Security definition")
+	}
+}
+
+// Security specifies the security scheme(s), identified by name, that protect the enclosing API,
+// Resource or Action, optionally restricting the request to a subset of the scheme's scopes. It
+// must appear inside API, Resource or Action. Example:
+//
+//	Security("jwt", func() {
+//		Scope("api:read")
+//	})
+func Security(scheme string, dsl ...func()) {
+	if len(dsl) > 1 {
+		ReportError("too many arguments given to Security")
+		return
+	}
+	req := &SecurityRequirementDefinition{SchemeName: scheme}
+	if len(dsl) == 1 {
+		if !executeDSL(dsl[0], req) {
+			return
+		}
+	}
+	if a, ok := actionDefinition(false); ok {
+		a.Security = req
+		requireAuthorizationHeader(scheme, a.Headers, func(h *AttributeDefinition) { a.Headers = h })
+	} else if r, ok := resourceDefinition(false); ok {
+		r.Security = req
+		requireAuthorizationHeader(scheme, r.Headers, func(h *AttributeDefinition) { r.Headers = h })
+	} else if api, ok := apiDefinition(true); ok {
+		api.Security = req
+	}
+}
+
+// requireAuthorizationHeader makes sure the Authorization header is declared and required
+// whenever scheme is a BasicAuthSecurity or JWTSecurity scheme whose credentials are not carried
+// in a query parameter or cookie, so the generated handler rejects
+// requests missing it before ever reaching the SecurityHandler. headers is the current Headers
+// attribute (possibly nil), and set stores the (possibly newly created) attribute back onto the
+// definition Security was called on.
+func requireAuthorizationHeader(scheme string, headers *AttributeDefinition, set func(*AttributeDefinition)) {
+	s, ok := Design.SecuritySchemes[scheme]
+	if !ok || (s.Kind != BasicAuthSecurityKind && s.Kind != JWTSecurityKind) {
+		return
+	}
+	if s.In != "" && s.In != "header" {
+		// Credentials are carried in a query parameter or cookie instead; injecting a
+		// required Authorization header would reject every otherwise-valid request.
+		return
+	}
+	if headers == nil {
+		headers = &AttributeDefinition{Type: Object{}}
+		set(headers)
+	}
+	if headers.ToObject() == nil {
+		headers.Type = Object{}
+	}
+	headers.Type.(Object)["Authorization"] = &AttributeDefinition{Type: String}
+	if !headers.IsRequired("Authorization") {
+		headers.Required = append(headers.Required, "Authorization")
+	}
+}
+
+// NoSecurity removes any security requirement inherited from the API or Resource for the
+// enclosing Action. It must appear inside Action.
+func NoSecurity() {
+	if a, ok := actionDefinition(true); ok {
+		a.NoSecurity = true
+	}
+}
+
+// securitySchemeDefinition returns the security scheme definition at the top of the DSL context
+// stack, reporting an error via ReportError if required is true and the context does not contain
+// one.
+func securitySchemeDefinition(required bool) (*SecuritySchemeDefinition, bool) {
+	s, ok := ctxStack.current().(*SecuritySchemeDefinition)
+	if !ok && required {
+		ReportError("not inside a security scheme definition")
+	}
+	return s, ok
+}
@@ -0,0 +1,51 @@
+package dsl
+
+import (
+	"testing"
+
+	. "github.com/raphael/goa/design"
+)
+
+func TestSecurityLocationDSL_SetsInAndName(t *testing.T) {
+	withFreshDesign(func() {
+		APIKeySecurity("api_key", func() { SecurityQuery("key") })
+		s := Design.SecuritySchemes["api_key"]
+		if s.In != "query" || s.Name != "key" {
+			t.Fatalf("got In=%q Name=%q, want In=query Name=key", s.In, s.Name)
+		}
+
+		JWTSecurity("jwt_cookie", func() { SecurityCookie("session") })
+		s = Design.SecuritySchemes["jwt_cookie"]
+		if s.In != "cookie" || s.Name != "session" {
+			t.Fatalf("got In=%q Name=%q, want In=cookie Name=session", s.In, s.Name)
+		}
+	})
+}
+
+func TestSecurity_InjectsAuthorizationHeaderForHeaderBasedSchemes(t *testing.T) {
+	withFreshDesign(func() {
+		BasicAuthSecurity("basic", func() {})
+
+		act := &ActionDefinition{Parent: &ResourceDefinition{}}
+		withContext(act, func() {
+			Security("basic")
+		})
+		if act.Headers == nil || !act.Headers.IsRequired("Authorization") {
+			t.Fatalf("expected Authorization to be required, got %+v", act.Headers)
+		}
+	})
+}
+
+func TestSecurity_DoesNotInjectAuthorizationHeaderForCookieSchemes(t *testing.T) {
+	withFreshDesign(func() {
+		JWTSecurity("jwt_cookie", func() { SecurityCookie("session") })
+
+		act := &ActionDefinition{Parent: &ResourceDefinition{}}
+		withContext(act, func() {
+			Security("jwt_cookie")
+		})
+		if act.Headers != nil && act.Headers.IsRequired("Authorization") {
+			t.Fatalf("did not expect Authorization to be injected for a cookie-based scheme, got %+v", act.Headers)
+		}
+	})
+}
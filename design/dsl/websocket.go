@@ -0,0 +1,143 @@
+package dsl
+
+import (
+	"time"
+
+	. "github.com/raphael/goa/design"
+)
+
+// WS creates a route using the "WS" pseudo HTTP verb, identifying an action that upgrades the
+// connection to a WebSocket rather than handling a regular HTTP request. It is used inside
+// Routing exactly like GET, POST etc. The path may not use the "*name" catch-all wildcard since
+// the generated handler needs the full, unambiguous path to match the upgrade request:
+//
+//	Action("monitor", func() {
+//		Routing(WS("/monitor/:id"))
+//		Stream(ServerStream)
+//		Response(OK)
+//	})
+//
+// An action's routes must either be all WS or all regular HTTP verbs; Routing reports an error
+// at design time if the two are mixed.
+func WS(path string) *RouteDefinition {
+	return &RouteDefinition{Verb: "WS", Path: path}
+}
+
+// Stream direction constants, passed as the first argument to Stream.
+const (
+	// ClientStream indicates the client sends a stream of Payload messages and receives a
+	// single Response.
+	ClientStream = "client"
+	// ServerStream indicates the client sends a single Payload and receives a stream of
+	// Response messages.
+	ServerStream = "server"
+	// BidirectionalStream indicates both the client and the server send a stream of messages.
+	BidirectionalStream = "bidirectional"
+)
+
+// Stream qualifies the enclosing Action as a WebSocket stream and indicates which direction(s)
+// carry multiple messages. It must appear inside an Action whose Routing is a WS route. Inside
+// the stream, Payload describes the schema of each message sent by the client and Response
+// describes each message sent by the server - for a ClientStream, Response still describes the
+// single final response. The optional DSL sets the keep-alive and size limits applied to the
+// connection:
+//
+//	Stream(BidirectionalStream, func() {
+//		PingInterval(30 * time.Second)
+//		WriteTimeout(10 * time.Second)
+//		MaxMessageSize(1 << 20)
+//	})
+func Stream(direction string, dsl ...func()) {
+	if len(dsl) > 1 {
+		ReportError("too many arguments given to Stream")
+		return
+	}
+	switch direction {
+	case ClientStream, ServerStream, BidirectionalStream:
+	default:
+		ReportError(`invalid Stream direction %q, must be one of "client", "server" or "bidirectional"`, direction)
+		return
+	}
+	if a, ok := actionDefinition(true); ok {
+		hasWS := false
+		for _, r := range a.Routes {
+			if r.Verb == "WS" {
+				hasWS = true
+			}
+		}
+		if !hasWS {
+			ReportError("Stream: action %q does not have a WS route", a.Name)
+			return
+		}
+		stream := &StreamDefinition{Direction: direction, Parent: a}
+		if len(dsl) == 1 {
+			if !executeDSL(dsl[0], stream) {
+				return
+			}
+		}
+		a.Stream = stream
+	}
+}
+
+// PingInterval sets how often the generated handler sends a WebSocket ping control frame to keep
+// the connection alive. It must appear inside a Stream definition.
+func PingInterval(d time.Duration) {
+	if s, ok := streamDefinition(true); ok {
+		s.PingInterval = d
+	}
+}
+
+// WriteTimeout sets the deadline applied to each write performed by the generated Send method.
+// It must appear inside a Stream definition.
+func WriteTimeout(d time.Duration) {
+	if s, ok := streamDefinition(true); ok {
+		s.WriteTimeout = d
+	}
+}
+
+// MaxMessageSize sets the maximum size in bytes of a single message the generated handler accepts
+// from the client, beyond which the connection is closed. It must appear inside a Stream
+// definition.
+func MaxMessageSize(bytes int64) {
+	if s, ok := streamDefinition(true); ok {
+		s.MaxMessageSize = bytes
+	}
+}
+
+// validateRouteVerbs reports an error if a's routes mix the "WS" pseudo-verb with a regular HTTP
+// verb: an action either upgrades the connection to a WebSocket or handles regular HTTP requests,
+// never both. Called from Routing so the check runs regardless of whether the action also uses
+// Stream.
+func validateRouteVerbs(a *ActionDefinition) {
+	hasWS, hasHTTP := false, false
+	for _, r := range a.Routes {
+		if r.Verb == "WS" {
+			hasWS = true
+		} else {
+			hasHTTP = true
+		}
+	}
+	if hasWS && hasHTTP {
+		ReportError("action %q combines a WS route with a regular HTTP route, this is not supported", a.Name)
+	}
+}
+
+// hasCatchAllWildcard reports whether path contains a "*name" httprouter catch-all wildcard.
+func hasCatchAllWildcard(path string) bool {
+	for _, r := range path {
+		if r == '*' {
+			return true
+		}
+	}
+	return false
+}
+
+// streamDefinition returns the Stream definition at the top of the DSL context stack, reporting
+// an error via ReportError if required is true and the context does not contain one.
+func streamDefinition(required bool) (*StreamDefinition, bool) {
+	s, ok := ctxStack.current().(*StreamDefinition)
+	if !ok && required {
+		ReportError("not inside a Stream definition")
+	}
+	return s, ok
+}
@@ -0,0 +1,10 @@
+package design
+
+// EncodingDefinition associates a media type with the Go package implementing its
+// goa/encoding.Decoder (for Consumes) or goa/encoding.Encoder (for Produces), as declared via the
+// Consumes/Produces DSL.
+type EncodingDefinition struct {
+	MIMETypes   []string
+	PackagePath string
+	Extensions  []string
+}
@@ -0,0 +1,45 @@
+package design
+
+// ToJSONSchema implements DataType. It returns the JSON schema document for a primitive.
+func (p Primitive) ToJSONSchema() interface{} {
+	switch p.Kind() {
+	case BooleanKind:
+		return map[string]interface{}{"type": "boolean"}
+	case IntegerKind:
+		return map[string]interface{}{"type": "integer"}
+	case NumberKind:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// ToJSONSchema implements DataType. It returns the JSON schema "object" document for o,
+// recursing into each member's own type.
+func (o Object) ToJSONSchema() interface{} {
+	props := make(map[string]interface{}, len(o))
+	for name, att := range o {
+		prop := att.ToJSONSchema()
+		if att.Description != "" {
+			if m, ok := prop.(map[string]interface{}); ok {
+				m["description"] = att.Description
+			}
+		}
+		props[name] = prop
+	}
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// ToJSONSchema returns the JSON schema document describing the attribute's type, as consumed by
+// goagen's Swagger generator to build a Payload or Response's "schema" field. When the attribute
+// describes an object it also adds the "required" key listing a.Required, since that list lives
+// on the AttributeDefinition rather than on the Object type itself.
+func (a *AttributeDefinition) ToJSONSchema() interface{} {
+	schema := a.Type.ToJSONSchema()
+	if _, ok := a.Type.(Object); ok && len(a.Required) > 0 {
+		if m, ok := schema.(map[string]interface{}); ok {
+			m["required"] = a.Required
+		}
+	}
+	return schema
+}
@@ -0,0 +1,37 @@
+package design
+
+import "testing"
+
+func TestAttributeDefinition_ToJSONSchemaRequired(t *testing.T) {
+	attr := &AttributeDefinition{
+		Type: Object{
+			"name": {Type: String},
+			"age":  {Type: Integer},
+		},
+		Required: []string{"name"},
+	}
+
+	schema, ok := attr.ToJSONSchema().(map[string]interface{})
+	if !ok {
+		t.Fatalf("ToJSONSchema() = %#v, want map[string]interface{}", attr.ToJSONSchema())
+	}
+	required, ok := schema["required"].([]string)
+	if !ok {
+		t.Fatalf(`schema["required"] = %#v, want []string`, schema["required"])
+	}
+	if len(required) != 1 || required[0] != "name" {
+		t.Fatalf("required = %v, want [name]", required)
+	}
+}
+
+func TestAttributeDefinition_ToJSONSchemaNoRequired(t *testing.T) {
+	attr := &AttributeDefinition{Type: Object{"name": {Type: String}}}
+
+	schema, ok := attr.ToJSONSchema().(map[string]interface{})
+	if !ok {
+		t.Fatalf("ToJSONSchema() = %#v, want map[string]interface{}", attr.ToJSONSchema())
+	}
+	if _, ok := schema["required"]; ok {
+		t.Fatalf(`schema["required"] = %v, want key absent`, schema["required"])
+	}
+}
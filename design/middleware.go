@@ -0,0 +1,7 @@
+package design
+
+// MiddlewareRef names a middleware registered in the middleware.Registry, as added to an API,
+// Resource or Action's middleware chain via the Use DSL.
+type MiddlewareRef struct {
+	Name string
+}
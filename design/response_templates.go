@@ -0,0 +1,15 @@
+package design
+
+// ResponseTemplates maps the well-known response names usable with the Response DSL (e.g.
+// Response(NotFound)) to the ResponseDefinition their HTTP status code defaults to. Response
+// copies the template and applies the caller's DSL (if any) on top, so multiple actions can
+// reference the same template and still override its Description or Headers independently.
+var ResponseTemplates = map[string]*ResponseDefinition{
+	"OK":           {Name: "OK", Status: 200},
+	"Created":      {Name: "Created", Status: 201},
+	"NoContent":    {Name: "NoContent", Status: 204},
+	"BadRequest":   {Name: "BadRequest", Status: 400},
+	"Unauthorized": {Name: "Unauthorized", Status: 401},
+	"Forbidden":    {Name: "Forbidden", Status: 403},
+	"NotFound":     {Name: "NotFound", Status: 404},
+}
@@ -0,0 +1,34 @@
+package design
+
+// SecuritySchemeKind identifies which of the DSL's security scheme builders (BasicAuthSecurity,
+// APIKeySecurity, JWTSecurity, OAuth2Security) created a SecuritySchemeDefinition.
+type SecuritySchemeKind int
+
+// Supported security scheme kinds, set on SecuritySchemeDefinition.Kind.
+const (
+	BasicAuthSecurityKind SecuritySchemeKind = iota + 1
+	APIKeySecurityKind
+	JWTSecurityKind
+	OAuth2SecurityKind
+)
+
+// SecuritySchemeDefinition describes a named authentication scheme declared via
+// BasicAuthSecurity, APIKeySecurity, JWTSecurity or OAuth2Security.
+type SecuritySchemeDefinition struct {
+	SchemeName       string
+	Kind             SecuritySchemeKind
+	Type             string
+	Description      string
+	In               string
+	Name             string
+	TokenURL         string
+	AuthorizationURL string
+	Scopes           map[string]string
+}
+
+// SecurityRequirementDefinition restricts an API, Resource or Action to clients authenticated
+// via the named scheme, optionally presenting a subset of its scopes, as set via Security.
+type SecurityRequirementDefinition struct {
+	SchemeName string
+	Scopes     []string
+}
@@ -0,0 +1,13 @@
+package design
+
+import "time"
+
+// StreamDefinition describes the direction and keep-alive/size settings of a WebSocket action, as
+// built up by the Stream DSL.
+type StreamDefinition struct {
+	Direction      string
+	Parent         *ActionDefinition
+	PingInterval   time.Duration
+	WriteTimeout   time.Duration
+	MaxMessageSize int64
+}
@@ -0,0 +1,150 @@
+// Package encoding defines the interfaces implemented by goa's request decoders and response
+// encoders and the registry generated services use to pick an implementation based on the
+// request's Content-Type or Accept header.
+package encoding
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Decoder unmarshals the data read from an HTTP request body into v. Implementations are
+// registered for a media type via the Consumes DSL.
+type Decoder interface {
+	Decode(v interface{}, r io.Reader) error
+}
+
+// Encoder marshals v and writes the result to the given writer. Implementations are registered
+// for a media type via the Produces DSL.
+type Encoder interface {
+	Encode(v interface{}, w io.Writer) error
+}
+
+// DecoderFactory creates a new Decoder, one per request, so that implementations may keep
+// per-request state (e.g. a streaming decoder).
+type DecoderFactory func() Decoder
+
+// EncoderFactory creates a new Encoder, one per request.
+type EncoderFactory func() Encoder
+
+// Registry maps media types to the factories that produce their Decoder and Encoder. The code
+// generated for a service populates a Registry from the Consumes/Produces DSL so that custom
+// codecs (e.g. protobuf) can be registered without modifying goa itself.
+type Registry struct {
+	decoders map[string]DecoderFactory
+	encoders map[string]EncoderFactory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: make(map[string]DecoderFactory),
+		encoders: make(map[string]EncoderFactory),
+	}
+}
+
+// RegisterDecoder associates f with mediaType.
+func (reg *Registry) RegisterDecoder(mediaType string, f DecoderFactory) {
+	reg.decoders[mediaType] = f
+}
+
+// RegisterEncoder associates f with mediaType.
+func (reg *Registry) RegisterEncoder(mediaType string, f EncoderFactory) {
+	reg.encoders[mediaType] = f
+}
+
+// Decoder returns the Decoder registered for the request's Content-Type, falling back to
+// defaultMediaType when the request does not set one. It returns false if no decoder is
+// registered for the resulting media type.
+func (reg *Registry) Decoder(r *http.Request, defaultMediaType string) (Decoder, bool) {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		ct = defaultMediaType
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		mt = ct
+	}
+	f, ok := reg.decoders[mt]
+	if !ok {
+		return nil, false
+	}
+	return f(), true
+}
+
+// Encoder returns the Encoder matching the highest quality media type accepted by the client, as
+// well as the corresponding media type so the caller can set the response Content-Type. It falls
+// back to defaultMediaType when the request has no Accept header or the header is "*/*". It
+// returns false if no encoder matches any of the accepted media types, in which case the caller
+// should respond with 406 Not Acceptable.
+func (reg *Registry) Encoder(r *http.Request, defaultMediaType string) (Encoder, string, bool) {
+	accept := r.Header.Get("Accept")
+	if accept == "" || accept == "*/*" {
+		if f, ok := reg.encoders[defaultMediaType]; ok {
+			return f(), defaultMediaType, true
+		}
+	}
+	for _, mt := range sortByQuality(accept) {
+		if mt == "*/*" {
+			if f, ok := reg.encoders[defaultMediaType]; ok {
+				return f(), defaultMediaType, true
+			}
+			continue
+		}
+		if f, ok := reg.encoders[mt]; ok {
+			return f(), mt, true
+		}
+	}
+	return nil, "", false
+}
+
+// acceptedType pairs a media type parsed out of an Accept header with its quality value.
+type acceptedType struct {
+	mediaType string
+	quality   float64
+}
+
+// sortByQuality parses an Accept header and returns the accepted media types ordered from
+// highest to lowest quality value, per RFC 7231.
+func sortByQuality(accept string) []string {
+	if accept == "" {
+		return nil
+	}
+	parts := strings.Split(accept, ",")
+	types := make([]acceptedType, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		mt := p
+		q := 1.0
+		if i := strings.Index(p, ";"); i != -1 {
+			mt = strings.TrimSpace(p[:i])
+			for _, param := range strings.Split(p[i+1:], ";") {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						q = v
+					}
+				}
+			}
+		}
+		if q == 0 {
+			// q=0 explicitly forbids this media type (RFC 7231 5.3.2); drop it so it never
+			// matches even if every other accepted type ranks lower.
+			continue
+		}
+		types = append(types, acceptedType{mediaType: mt, quality: q})
+	}
+	sort.SliceStable(types, func(i, j int) bool { return types[i].quality > types[j].quality })
+	res := make([]string, len(types))
+	for i, t := range types {
+		res[i] = t.mediaType
+	}
+	return res
+}
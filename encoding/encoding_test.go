@@ -0,0 +1,34 @@
+package encoding
+
+import "testing"
+
+func TestSortByQuality(t *testing.T) {
+	cases := []struct {
+		accept string
+		want   []string
+	}{
+		{"", nil},
+		{"application/json", []string{"application/json"}},
+		{
+			"text/plain;q=0.5, application/json",
+			[]string{"application/json", "text/plain"},
+		},
+		{
+			"application/json;q=0, text/plain;q=0.5",
+			[]string{"text/plain"},
+		},
+	}
+	for _, c := range cases {
+		got := sortByQuality(c.accept)
+		if len(got) != len(c.want) {
+			t.Errorf("sortByQuality(%q) = %v, want %v", c.accept, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("sortByQuality(%q) = %v, want %v", c.accept, got, c.want)
+				break
+			}
+		}
+	}
+}
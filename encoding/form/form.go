@@ -0,0 +1,42 @@
+// Package form implements the goa/encoding Decoder interface for the
+// "application/x-www-form-urlencoded" media type. Its import path is the PackagePath to pass to
+// Consumes("application/x-www-form-urlencoded", ...) to select it.
+package form
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/raphael/goa/encoding"
+)
+
+// Decoder decodes request bodies encoded as "application/x-www-form-urlencoded" into a
+// map[string][]string, mirroring url.Values.
+type Decoder struct{}
+
+// Decode implements encoding.Decoder. v must be a *url.Values or a *map[string][]string.
+func (d *Decoder) Decode(v interface{}, r io.Reader) error {
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+	switch t := v.(type) {
+	case *url.Values:
+		*t = values
+	case *map[string][]string:
+		*t = map[string][]string(values)
+	default:
+		return fmt.Errorf("form: cannot decode into %T", v)
+	}
+	return nil
+}
+
+// NewDecoder is an encoding.DecoderFactory for the "application/x-www-form-urlencoded" media
+// type.
+func NewDecoder() encoding.Decoder { return &Decoder{} }
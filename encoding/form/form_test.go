@@ -0,0 +1,29 @@
+package form
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	var values url.Values
+	d := &Decoder{}
+	if err := d.Decode(&values, strings.NewReader("name=bottle&name=cellar&vintage=2015")); err != nil {
+		t.Fatalf("Decode failed: %s", err)
+	}
+	if got := values.Get("vintage"); got != "2015" {
+		t.Errorf("values[vintage] = %q, want 2015", got)
+	}
+	if got := values["name"]; len(got) != 2 || got[0] != "bottle" || got[1] != "cellar" {
+		t.Errorf("values[name] = %v, want [bottle cellar]", got)
+	}
+}
+
+func TestDecoder_DecodeUnsupportedType(t *testing.T) {
+	var dst int
+	d := &Decoder{}
+	if err := d.Decode(&dst, strings.NewReader("a=b")); err == nil {
+		t.Error("expected an error decoding into an unsupported type, got nil")
+	}
+}
@@ -0,0 +1,33 @@
+// Package json implements the goa/encoding Decoder and Encoder interfaces for the
+// "application/json" media type. Its import path is the PackagePath to pass to
+// Consumes("application/json", ...) / Produces("application/json", ...) to select it.
+package json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/raphael/goa/encoding"
+)
+
+// Decoder decodes request bodies encoded as JSON.
+type Decoder struct{}
+
+// Decode implements encoding.Decoder.
+func (d *Decoder) Decode(v interface{}, r io.Reader) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// Encoder encodes response bodies as JSON.
+type Encoder struct{}
+
+// Encode implements encoding.Encoder.
+func (e *Encoder) Encode(v interface{}, w io.Writer) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// NewDecoder is an encoding.DecoderFactory for the "application/json" media type.
+func NewDecoder() encoding.Decoder { return &Decoder{} }
+
+// NewEncoder is an encoding.EncoderFactory for the "application/json" media type.
+func NewEncoder() encoding.Encoder { return &Encoder{} }
@@ -0,0 +1,33 @@
+// Package xml implements the goa/encoding Decoder and Encoder interfaces for the
+// "application/xml" media type. Its import path is the PackagePath to pass to
+// Consumes("application/xml", ...) / Produces("application/xml", ...) to select it.
+package xml
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/raphael/goa/encoding"
+)
+
+// Decoder decodes request bodies encoded as XML.
+type Decoder struct{}
+
+// Decode implements encoding.Decoder.
+func (d *Decoder) Decode(v interface{}, r io.Reader) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// Encoder encodes response bodies as XML.
+type Encoder struct{}
+
+// Encode implements encoding.Encoder.
+func (e *Encoder) Encode(v interface{}, w io.Writer) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// NewDecoder is an encoding.DecoderFactory for the "application/xml" media type.
+func NewDecoder() encoding.Decoder { return &Decoder{} }
+
+// NewEncoder is an encoding.EncoderFactory for the "application/xml" media type.
+func NewEncoder() encoding.Encoder { return &Encoder{} }
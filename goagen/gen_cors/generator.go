@@ -0,0 +1,69 @@
+// Package gencors resolves the CORS policies declared on the design's API, Resource and Action
+// definitions into the middleware.Middleware goagen installs in front of each action's generated
+// handler.
+package gencors
+
+import (
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/middleware"
+)
+
+// ForAction returns the middleware.CORS middleware configured for act, resolving the policies
+// declared on api, act.Parent and act itself per origin: a policy an Action or Resource declares
+// for a given origin overrides the one it would otherwise inherit for that origin, as documented
+// by the CORS DSL, while origins left untouched at a narrower scope keep inheriting their
+// enclosing definition's policy.
+func ForAction(api *design.APIDefinition, act *design.ActionDefinition) middleware.Middleware {
+	return middleware.CORS(ResolveAction(api, act)...)
+}
+
+// ResolveAction returns the list of CORS policies that apply to act, one per distinct origin,
+// after applying the API < Resource < Action override order. A resolved policy whose Methods is
+// left unset by its DSL defaults to the union of the HTTP verbs used by act's own routes, since
+// that is the set of methods the browser may actually invoke on this particular action; the "WS"
+// pseudo-verb is never included since a WebSocket upgrade is not subject to CORS preflight.
+func ResolveAction(api *design.APIDefinition, act *design.ActionDefinition) []*design.CORSDefinition {
+	byOrigin := make(map[string]*design.CORSDefinition)
+	var order []string
+	merge := func(policies []*design.CORSDefinition) {
+		for _, p := range policies {
+			if _, ok := byOrigin[p.Origin]; !ok {
+				order = append(order, p.Origin)
+			}
+			byOrigin[p.Origin] = p
+		}
+	}
+	merge(api.CORS)
+	if act.Parent != nil {
+		merge(act.Parent.CORS)
+	}
+	merge(act.CORS)
+
+	methods := methodsFor(act)
+	resolved := make([]*design.CORSDefinition, len(order))
+	for i, origin := range order {
+		p := byOrigin[origin]
+		if len(p.Methods) == 0 && len(methods) > 0 {
+			cp := *p
+			cp.Methods = methods
+			p = &cp
+		}
+		resolved[i] = p
+	}
+	return resolved
+}
+
+// methodsFor returns the deduplicated, order-preserving list of HTTP verbs used by act's routes,
+// excluding the "WS" pseudo-verb.
+func methodsFor(act *design.ActionDefinition) []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, r := range act.Routes {
+		if r.Verb == "WS" || seen[r.Verb] {
+			continue
+		}
+		seen[r.Verb] = true
+		methods = append(methods, r.Verb)
+	}
+	return methods
+}
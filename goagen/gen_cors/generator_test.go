@@ -0,0 +1,74 @@
+package gencors
+
+import (
+	"testing"
+
+	"github.com/raphael/goa/design"
+)
+
+func TestResolveAction_OverridesByOrigin(t *testing.T) {
+	api := &design.APIDefinition{
+		CORS: []*design.CORSDefinition{
+			{Origin: "https://goa.design", MaxAge: 100},
+			{Origin: "https://other.design", MaxAge: 50},
+		},
+	}
+	res := &design.ResourceDefinition{
+		CORS: []*design.CORSDefinition{
+			{Origin: "https://goa.design", MaxAge: 200},
+		},
+	}
+	act := &design.ActionDefinition{
+		Parent: res,
+		Routes: []*design.RouteDefinition{{Verb: "GET"}, {Verb: "POST"}, {Verb: "WS"}},
+	}
+
+	resolved := ResolveAction(api, act)
+	if len(resolved) != 2 {
+		t.Fatalf("got %d resolved policies, want 2", len(resolved))
+	}
+	byOrigin := make(map[string]*design.CORSDefinition, len(resolved))
+	for _, p := range resolved {
+		byOrigin[p.Origin] = p
+	}
+
+	if got := byOrigin["https://goa.design"].MaxAge; got != 200 {
+		t.Fatalf("resource policy should override api policy for the same origin, MaxAge = %d, want 200", got)
+	}
+	if got := byOrigin["https://other.design"].MaxAge; got != 50 {
+		t.Fatalf("api-only origin should be inherited unchanged, MaxAge = %d, want 50", got)
+	}
+}
+
+func TestResolveAction_DefaultsMethodsToRoutingVerbsExcludingWS(t *testing.T) {
+	api := &design.APIDefinition{
+		CORS: []*design.CORSDefinition{{Origin: "*"}},
+	}
+	act := &design.ActionDefinition{
+		Parent: &design.ResourceDefinition{},
+		Routes: []*design.RouteDefinition{{Verb: "GET"}, {Verb: "WS"}},
+	}
+
+	resolved := ResolveAction(api, act)
+	if len(resolved) != 1 {
+		t.Fatalf("got %d resolved policies, want 1", len(resolved))
+	}
+	if got := resolved[0].Methods; len(got) != 1 || got[0] != "GET" {
+		t.Fatalf("Methods = %v, want [GET] (WS excluded)", got)
+	}
+}
+
+func TestResolveAction_DoesNotOverrideExplicitMethods(t *testing.T) {
+	api := &design.APIDefinition{
+		CORS: []*design.CORSDefinition{{Origin: "*", Methods: []string{"PATCH"}}},
+	}
+	act := &design.ActionDefinition{
+		Parent: &design.ResourceDefinition{},
+		Routes: []*design.RouteDefinition{{Verb: "GET"}},
+	}
+
+	resolved := ResolveAction(api, act)
+	if got := resolved[0].Methods; len(got) != 1 || got[0] != "PATCH" {
+		t.Fatalf("Methods = %v, want explicit [PATCH] preserved", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Package genencoding builds the encoding.Registry a generated action handler uses to decode the
+// request payload and encode the response, from the design's Consumes/Produces DSL.
+package genencoding
+
+import (
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/encoding"
+	encform "github.com/raphael/goa/encoding/form"
+	encjson "github.com/raphael/goa/encoding/json"
+	encxml "github.com/raphael/goa/encoding/xml"
+)
+
+// codec pairs the decoder/encoder factories a built-in codec package exposes.
+type codec struct {
+	decoder encoding.DecoderFactory
+	encoder encoding.EncoderFactory
+}
+
+// knownCodecs maps the PackagePath an EncodingDefinition declares to the factories implemented
+// by that package. A PackagePath is just a string in the design, so it cannot be turned into a Go
+// import at runtime; this table bridges it to the codec packages goa ships (encoding/json,
+// encoding/xml, encoding/form). Applications registering a custom codec under their own
+// PackagePath must call encoding.Registry.RegisterDecoder/RegisterEncoder themselves.
+var knownCodecs = map[string]codec{
+	"github.com/raphael/goa/encoding/json": {
+		decoder: func() encoding.Decoder { return encjson.NewDecoder() },
+		encoder: func() encoding.Encoder { return encjson.NewEncoder() },
+	},
+	"github.com/raphael/goa/encoding/xml": {
+		decoder: func() encoding.Decoder { return encxml.NewDecoder() },
+		encoder: func() encoding.Encoder { return encxml.NewEncoder() },
+	},
+	"github.com/raphael/goa/encoding/form": {
+		decoder: func() encoding.Decoder { return encform.NewDecoder() },
+	},
+}
+
+// ForAction builds the encoding.Registry for act, registering every codec known to knownCodecs
+// among act's own Consumes/Produces, falling back to act.Parent's and then api's when act does
+// not override them - the same scope-resolution order Consumes/Produces apply at design time.
+func ForAction(api *design.APIDefinition, act *design.ActionDefinition) *encoding.Registry {
+	reg := encoding.NewRegistry()
+	register(reg, consumesFor(api, act), false)
+	register(reg, producesFor(api, act), true)
+	return reg
+}
+
+func consumesFor(api *design.APIDefinition, act *design.ActionDefinition) []*design.EncodingDefinition {
+	if len(act.Consumes) > 0 {
+		return act.Consumes
+	}
+	if act.Parent != nil && len(act.Parent.Consumes) > 0 {
+		return act.Parent.Consumes
+	}
+	return api.Consumes
+}
+
+func producesFor(api *design.APIDefinition, act *design.ActionDefinition) []*design.EncodingDefinition {
+	if len(act.Produces) > 0 {
+		return act.Produces
+	}
+	if act.Parent != nil && len(act.Parent.Produces) > 0 {
+		return act.Parent.Produces
+	}
+	return api.Produces
+}
+
+func register(reg *encoding.Registry, defs []*design.EncodingDefinition, produces bool) {
+	for _, d := range defs {
+		c, ok := knownCodecs[d.PackagePath]
+		if !ok {
+			continue
+		}
+		for _, mt := range d.MIMETypes {
+			if produces {
+				if c.encoder != nil {
+					reg.RegisterEncoder(mt, c.encoder)
+				}
+			} else if c.decoder != nil {
+				reg.RegisterDecoder(mt, c.decoder)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package genencoding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raphael/goa/design"
+)
+
+func TestForAction_RegistersKnownCodecsAndFallsBackToAPI(t *testing.T) {
+	api := &design.APIDefinition{
+		Consumes: []*design.EncodingDefinition{
+			{MIMETypes: []string{"application/json"}, PackagePath: "github.com/raphael/goa/encoding/json"},
+		},
+		Produces: []*design.EncodingDefinition{
+			{MIMETypes: []string{"application/xml"}, PackagePath: "github.com/raphael/goa/encoding/xml"},
+		},
+	}
+	act := &design.ActionDefinition{Parent: &design.ResourceDefinition{}}
+
+	reg := ForAction(api, act)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Content-Type", "application/json")
+	if _, ok := reg.Decoder(r, "application/json"); !ok {
+		t.Fatal("expected a decoder registered for application/json, inherited from the API")
+	}
+
+	r2 := httptest.NewRequest(http.MethodGet, "/", nil)
+	r2.Header.Set("Accept", "application/xml")
+	if _, mt, ok := reg.Encoder(r2, "application/xml"); !ok || mt != "application/xml" {
+		t.Fatalf("expected an encoder registered for application/xml, got mt=%q ok=%v", mt, ok)
+	}
+}
+
+func TestForAction_ActionOverridesAPI(t *testing.T) {
+	api := &design.APIDefinition{
+		Consumes: []*design.EncodingDefinition{
+			{MIMETypes: []string{"application/json"}, PackagePath: "github.com/raphael/goa/encoding/json"},
+		},
+	}
+	act := &design.ActionDefinition{
+		Parent: &design.ResourceDefinition{},
+		Consumes: []*design.EncodingDefinition{
+			{MIMETypes: []string{"application/xml"}, PackagePath: "github.com/raphael/goa/encoding/xml"},
+		},
+	}
+
+	reg := ForAction(api, act)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Content-Type", "application/json")
+	if _, ok := reg.Decoder(r, "application/json"); ok {
+		t.Fatal("action-level Consumes should replace, not merge with, the API's")
+	}
+}
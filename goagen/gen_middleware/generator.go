@@ -0,0 +1,52 @@
+// Package genmiddleware resolves the middleware chain declared via the Use/Skip DSL into the
+// middleware.Middleware a generated action handler installs in front of itself.
+package genmiddleware
+
+import (
+	"fmt"
+
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/middleware"
+)
+
+// ForAction returns the middleware.Middleware for act, chaining, in order, the API's middleware,
+// then its resource's, then the action's own - the same precedence documented by the Use DSL.
+// API and resource middleware whose name act.Skipped lists are left out. It returns an error
+// naming the first reference that does not resolve in middleware.Registry.
+func ForAction(api *design.APIDefinition, act *design.ActionDefinition) (middleware.Middleware, error) {
+	var refs []*design.MiddlewareRef
+	for _, m := range api.Middleware {
+		if skipped(act, m.Name) {
+			continue
+		}
+		refs = append(refs, m)
+	}
+	if act.Parent != nil {
+		for _, m := range act.Parent.Middleware {
+			if skipped(act, m.Name) {
+				continue
+			}
+			refs = append(refs, m)
+		}
+	}
+	refs = append(refs, act.Middleware...)
+
+	chain := make([]middleware.Middleware, len(refs))
+	for i, ref := range refs {
+		m, ok := middleware.Registry[ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("genmiddleware: middleware %q is not registered", ref.Name)
+		}
+		chain[i] = m
+	}
+	return middleware.Chain(chain...), nil
+}
+
+func skipped(act *design.ActionDefinition, name string) bool {
+	for _, s := range act.Skipped {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package genmiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/middleware"
+)
+
+func TestForAction_ChainsAPIResourceAndActionSkippingSkipped(t *testing.T) {
+	var order []string
+	track := func(name string) middleware.Middleware {
+		return func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				h.ServeHTTP(w, r)
+			})
+		}
+	}
+	middleware.Register("genmw-test-a", track("a"))
+	middleware.Register("genmw-test-b", track("b"))
+	middleware.Register("genmw-test-c", track("c"))
+
+	api := &design.APIDefinition{Middleware: []*design.MiddlewareRef{{Name: "genmw-test-a"}}}
+	res := &design.ResourceDefinition{Middleware: []*design.MiddlewareRef{{Name: "genmw-test-b"}}}
+	act := &design.ActionDefinition{
+		Parent:     res,
+		Middleware: []*design.MiddlewareRef{{Name: "genmw-test-c"}},
+	}
+
+	chain, err := ForAction(api, act)
+	if err != nil {
+		t.Fatalf("ForAction() error = %s", err)
+	}
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Fatalf("order = %v, want [a b c]", order)
+	}
+}
+
+func TestForAction_SkipsSkippedResourceMiddleware(t *testing.T) {
+	middleware.Register("genmw-test-skip", func(h http.Handler) http.Handler { return h })
+
+	res := &design.ResourceDefinition{Middleware: []*design.MiddlewareRef{{Name: "genmw-test-skip"}}}
+	act := &design.ActionDefinition{Parent: res, Skipped: []string{"genmw-test-skip"}}
+
+	chain, err := ForAction(&design.APIDefinition{}, act)
+	if err != nil {
+		t.Fatalf("ForAction() error = %s", err)
+	}
+	called := false
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !called {
+		t.Fatal("expected the final handler to still run")
+	}
+}
+
+func TestForAction_UnknownMiddlewareErrors(t *testing.T) {
+	act := &design.ActionDefinition{
+		Parent:     &design.ResourceDefinition{},
+		Middleware: []*design.MiddlewareRef{{Name: "genmw-test-does-not-exist"}},
+	}
+	if _, err := ForAction(&design.APIDefinition{}, act); err == nil {
+		t.Fatal("expected an error for an unregistered middleware name")
+	}
+}
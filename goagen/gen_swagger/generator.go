@@ -0,0 +1,305 @@
+// Package genswagger generates an OpenAPI 2.0 ("Swagger") specification document from a goa API
+// design. It walks the APIDefinition produced by the design DSL and translates every resource and
+// action into the corresponding Swagger path item, reusing the Params, Headers, Payload and
+// Response definitions to build the parameter and schema sections of the document.
+package genswagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"bitbucket.org/pkg/inflect"
+	"github.com/raphael/goa/design"
+)
+
+// wildcardRegex matches the httprouter ":name" and "*name" path segments so they can be
+// translated into the "{name}" Swagger path template syntax.
+var wildcardRegex = regexp.MustCompile(`[:*]([^/]+)`)
+
+// Swagger is the root object of a Swagger 2.0 specification document.
+type Swagger struct {
+	Swagger             string                          `json:"swagger"`
+	Info                *Info                           `json:"info"`
+	BasePath            string                          `json:"basePath,omitempty"`
+	Paths               map[string]*PathItem            `json:"paths"`
+	Definitions         map[string]interface{}          `json:"definitions,omitempty"`
+	SecurityDefinitions map[string]*SecurityScheme       `json:"securityDefinitions,omitempty"`
+	Security            []map[string][]string            `json:"security,omitempty"`
+}
+
+// SecurityScheme describes a security scheme declared via BasicAuthSecurity, APIKeySecurity,
+// JWTSecurity or OAuth2Security.
+type SecurityScheme struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	In               string            `json:"in,omitempty"`
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
+}
+
+// Info describes the metadata of the generated API.
+type Info struct {
+	Title          string `json:"title"`
+	Description    string `json:"description,omitempty"`
+	Version        string `json:"version"`
+	TermsOfService string `json:"termsOfService,omitempty"`
+}
+
+// PathItem groups the operations available on a single Swagger path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// Operation describes a single Swagger operation, traceable back to the goa action it was
+// generated from via OperationID.
+type Operation struct {
+	OperationID  string                 `json:"operationId"`
+	Summary      string                 `json:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty"`
+	Parameters   []*Parameter           `json:"parameters,omitempty"`
+	Responses    map[string]*Response   `json:"responses"`
+	Security     *[]map[string][]string `json:"security,omitempty"`
+}
+
+// ExternalDocs mirrors the Docs/ExternalDocs DSL.
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// Parameter describes a path, query, header or body parameter.
+type Parameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Schema      interface{} `json:"schema,omitempty"`
+}
+
+// Response describes a single Swagger response.
+type Response struct {
+	Description string                 `json:"description"`
+	Schema      interface{}            `json:"schema,omitempty"`
+	Headers     map[string]interface{} `json:"headers,omitempty"`
+}
+
+// Generate produces the Swagger specification for the given API definition.
+func Generate(api *design.APIDefinition) (*Swagger, error) {
+	s := &Swagger{
+		Swagger: "2.0",
+		Info: &Info{
+			Title:   api.Title,
+			Version: "1.0",
+		},
+		Paths: make(map[string]*PathItem),
+	}
+	if api.Docs != nil {
+		s.Info.Description = api.Docs.Description
+	}
+	if len(api.SecuritySchemes) > 0 {
+		s.SecurityDefinitions = make(map[string]*SecurityScheme, len(api.SecuritySchemes))
+		for name, scheme := range api.SecuritySchemes {
+			s.SecurityDefinitions[name] = securitySchemeFor(scheme)
+		}
+	}
+	if api.Security != nil {
+		s.Security = securityRequirementsFor(api.Security)
+	}
+	var err error
+	api.IterateResources(func(res *design.ResourceDefinition) error {
+		return res.IterateActions(func(act *design.ActionDefinition) error {
+			for _, route := range act.Routes {
+				item, ok := s.Paths[swaggerPath(route.Path)]
+				if !ok {
+					item = new(PathItem)
+					s.Paths[swaggerPath(route.Path)] = item
+				}
+				op, opErr := operationFor(res, act, route)
+				if opErr != nil {
+					err = opErr
+					return opErr
+				}
+				setOperation(item, route.Verb, op)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GenerateJSON produces the Swagger specification as indented JSON, ready to be written to
+// "swagger.json" or served at the path set via the Swagger DSL.
+func GenerateJSON(api *design.APIDefinition) ([]byte, error) {
+	s, err := Generate(api)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// operationFor builds the Swagger operation for a single action route, deriving the
+// OperationID from the action and resource names so generated operations are traceable back to
+// the design.
+func operationFor(res *design.ResourceDefinition, act *design.ActionDefinition, route *design.RouteDefinition) (*Operation, error) {
+	op := &Operation{
+		OperationID: inflect.Camelize(act.Name) + inflect.Camelize(res.Name),
+		Description: act.Description,
+		Responses:   make(map[string]*Response),
+	}
+	if act.Docs != nil {
+		op.ExternalDocs = &ExternalDocs{Description: act.Docs.Description, URL: act.Docs.URL}
+	}
+	switch {
+	case act.NoSecurity:
+		op.Security = &[]map[string][]string{}
+	case act.Security != nil:
+		reqs := securityRequirementsFor(act.Security)
+		op.Security = &reqs
+	case res.Security != nil:
+		reqs := securityRequirementsFor(res.Security)
+		op.Security = &reqs
+	}
+	wildcards := make(map[string]bool)
+	for _, name := range design.ExtractWildcards(route.Path) {
+		wildcards[name] = true
+	}
+	if act.Params != nil {
+		for name, attr := range act.Params.ToObject() {
+			in := "query"
+			if wildcards[name] {
+				in = "path"
+			}
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        name,
+				In:          in,
+				Description: attr.Description,
+				Required:    in == "path" || act.Params.IsRequired(name),
+				Type:        swaggerType(attr.Type),
+			})
+		}
+	}
+	if act.Headers != nil {
+		for name, attr := range act.Headers.ToObject() {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        name,
+				In:          "header",
+				Description: attr.Description,
+				Required:    act.Headers.IsRequired(name),
+				Type:        swaggerType(attr.Type),
+			})
+		}
+	}
+	if act.Payload != nil {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     "payload",
+			In:       "body",
+			Required: true,
+			Schema:   schemaFor(act.Payload),
+		})
+	}
+	for _, resp := range act.Responses {
+		r := &Response{Description: resp.Description}
+		if resp.MediaType != "" {
+			if mt, ok := design.Design.MediaTypes[resp.MediaType]; ok {
+				r.Schema = schemaFor(mt)
+			}
+		}
+		op.Responses[fmt.Sprintf("%d", resp.Status)] = r
+	}
+	return op, nil
+}
+
+// securitySchemeFor translates a design security scheme into its Swagger securityDefinitions
+// entry.
+func securitySchemeFor(scheme *design.SecuritySchemeDefinition) *SecurityScheme {
+	s := &SecurityScheme{
+		Type:             scheme.Type,
+		Name:             scheme.Name,
+		In:               scheme.In,
+		AuthorizationURL: scheme.AuthorizationURL,
+		TokenURL:         scheme.TokenURL,
+		Scopes:           scheme.Scopes,
+	}
+	if scheme.Kind == design.OAuth2SecurityKind {
+		switch {
+		case scheme.AuthorizationURL != "" && scheme.TokenURL != "":
+			s.Flow = "accessCode"
+		case scheme.AuthorizationURL != "":
+			s.Flow = "implicit"
+		default:
+			s.Flow = "application"
+		}
+	}
+	return s
+}
+
+// securityRequirementsFor translates a design security requirement into the Swagger "security"
+// array format, i.e. a single-element list mapping the scheme name to its required scopes.
+func securityRequirementsFor(req *design.SecurityRequirementDefinition) []map[string][]string {
+	scopes := req.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return []map[string][]string{{req.SchemeName: scopes}}
+}
+
+// schemaFor returns the JSON schema representation of a user type, reusing the type's own
+// ToJSONSchema helper when available.
+func schemaFor(ut design.DataStructure) interface{} {
+	return ut.Definition().ToJSONSchema()
+}
+
+// swaggerType maps a goa primitive DataType to the corresponding Swagger "type" string.
+func swaggerType(t design.DataType) string {
+	switch t.Kind() {
+	case design.IntegerKind:
+		return "integer"
+	case design.NumberKind:
+		return "number"
+	case design.BooleanKind:
+		return "boolean"
+	case design.ArrayKind:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// swaggerPath rewrites httprouter wildcards (":name", "*name") into the "{name}" syntax used by
+// Swagger path templates.
+func swaggerPath(path string) string {
+	return wildcardRegex.ReplaceAllStringFunc(path, func(m string) string {
+		return "{" + strings.TrimLeft(m, ":*") + "}"
+	})
+}
+
+// setOperation assigns op to the PathItem slot matching verb, ignoring verbs PathItem has no slot
+// for (HEAD, TRACE, CONNECT and the WS pseudo-verb).
+func setOperation(item *PathItem, verb string, op *Operation) {
+	switch verb {
+	case "GET":
+		item.Get = op
+	case "PUT":
+		item.Put = op
+	case "POST":
+		item.Post = op
+	case "DELETE":
+		item.Delete = op
+	case "PATCH":
+		item.Patch = op
+	}
+}
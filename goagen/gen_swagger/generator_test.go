@@ -0,0 +1,20 @@
+package genswagger
+
+import "testing"
+
+func TestSwaggerPath(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/accounts", "/accounts"},
+		{"/accounts/:id", "/accounts/{id}"},
+		{"/accounts/:id/bottles/:bottleID", "/accounts/{id}/bottles/{bottleID}"},
+		{"/files/*path", "/files/{path}"},
+	}
+	for _, c := range cases {
+		if got := swaggerPath(c.path); got != c.want {
+			t.Errorf("swaggerPath(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
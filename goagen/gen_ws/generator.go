@@ -0,0 +1,33 @@
+// Package genws builds the http.HandlerFunc a generated WS action installs: it upgrades the
+// connection per the action's Stream definition and hands the resulting connection to the
+// application's Handler.
+package genws
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/ws"
+)
+
+// Handler drives a single upgraded connection, exchanging messages via conn.Send/conn.Recv until
+// it returns; ForAction closes conn once it does.
+type Handler func(conn *ws.Conn, r *http.Request)
+
+// ForAction returns the http.HandlerFunc act's WS route installs. It errors if act does not
+// declare a Stream, since there would be no StreamDefinition to configure the upgrade from.
+func ForAction(act *design.ActionDefinition, h Handler) (http.HandlerFunc, error) {
+	if act.Stream == nil {
+		return nil, fmt.Errorf("genws: action %q does not declare a Stream", act.Name)
+	}
+	stream := act.Stream
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrade(w, r, stream)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		h(conn, r)
+	}, nil
+}
@@ -0,0 +1,60 @@
+package genws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/ws"
+)
+
+func TestForAction_ErrorsWithoutStream(t *testing.T) {
+	act := &design.ActionDefinition{Name: "monitor"}
+	if _, err := ForAction(act, func(conn *ws.Conn, r *http.Request) {}); err == nil {
+		t.Fatal("expected an error for an action without a Stream")
+	}
+}
+
+func TestForAction_UpgradesAndDrivesHandler(t *testing.T) {
+	act := &design.ActionDefinition{Name: "monitor", Stream: &design.StreamDefinition{}}
+	driven := make(chan struct{})
+	h, err := ForAction(act, func(conn *ws.Conn, r *http.Request) {
+		var msg map[string]string
+		if err := conn.Recv(&msg); err != nil {
+			t.Errorf("Recv failed: %s", err)
+			return
+		}
+		if err := conn.Send(msg); err != nil {
+			t.Errorf("Send failed: %s", err)
+		}
+		close(driven)
+	})
+	if err != nil {
+		t.Fatalf("ForAction() error = %s", err)
+	}
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %s", err)
+	}
+	defer c.Close()
+
+	if err := c.WriteJSON(map[string]string{"hello": "goa"}); err != nil {
+		t.Fatalf("WriteJSON failed: %s", err)
+	}
+	var got map[string]string
+	if err := c.ReadJSON(&got); err != nil {
+		t.Fatalf("ReadJSON failed: %s", err)
+	}
+	if got["hello"] != "goa" {
+		t.Fatalf("got %v, want {hello: goa}", got)
+	}
+	<-driven
+}
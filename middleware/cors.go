@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/raphael/goa/design"
+)
+
+// CORS returns a middleware generated from the design's CORS DSL. It short-circuits OPTIONS
+// preflight requests with a 204 and the computed Access-Control-Allow-* headers; on actual
+// requests it echoes the Origin header when it matches one of the policies, sets
+// Access-Control-Expose-Headers and adds Vary: Origin (plus Access-Control-Request-Method/Headers
+// on preflight requests). Requests whose Origin does not match any policy, or that do not set an
+// Origin header at all, are passed through unmodified.
+func CORS(policies ...*design.CORSDefinition) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			policy := matchCORSPolicy(policies, origin)
+			if policy == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			preflight := r.Method == http.MethodOptions
+			vary := append([]string{"Origin"}, policy.Vary...)
+			if preflight {
+				vary = append(vary, "Access-Control-Request-Method", "Access-Control-Request-Headers")
+			}
+			for _, v := range vary {
+				w.Header().Add("Vary", v)
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if policy.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			if !preflight {
+				if len(policy.Exposed) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(policy.Exposed, ", "))
+				}
+				h.ServeHTTP(w, r)
+				return
+			}
+			if len(policy.Methods) > 0 {
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(policy.Methods, ", "))
+			}
+			if len(policy.Headers) > 0 {
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.Headers, ", "))
+			}
+			if policy.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(policy.MaxAge))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// matchCORSPolicy returns the first policy in policies whose origin matches origin, or nil if
+// none do.
+func matchCORSPolicy(policies []*design.CORSDefinition, origin string) *design.CORSDefinition {
+	for _, p := range policies {
+		if p.MatchesOrigin(origin) {
+			return p
+		}
+	}
+	return nil
+}
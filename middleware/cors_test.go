@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raphael/goa/design"
+)
+
+func TestCORS_Preflight(t *testing.T) {
+	policy := &design.CORSDefinition{
+		Origin:           "https://goa.design",
+		Methods:          []string{"GET", "POST"},
+		Headers:          []string{"X-Shared-Secret"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+	h := CORS(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("preflight request should not reach the wrapped handler")
+	}))
+
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("Origin", "https://goa.design")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Fatalf("Access-Control-Allow-Methods = %q, want %q", got, "GET, POST")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); got != "X-Shared-Secret" {
+		t.Fatalf("Access-Control-Allow-Headers = %q, want %q", got, "X-Shared-Secret")
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Fatalf("Access-Control-Max-Age = %q, want %q", got, "600")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	policy := &design.CORSDefinition{Origin: "*", Exposed: []string{"X-Total-Count"}}
+	called := false
+	h := CORS(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Origin", "https://goa.design")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Fatal("expected the wrapped handler to run for a non-preflight request")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://goa.design" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want the echoed origin", got)
+	}
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Total-Count" {
+		t.Fatalf("Access-Control-Expose-Headers = %q, want %q", got, "X-Total-Count")
+	}
+}
+
+func TestCORS_PassesThroughUnmatchedOrigin(t *testing.T) {
+	policy := &design.CORSDefinition{Origin: "https://goa.design"}
+	called := false
+	h := CORS(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Origin", "https://evil.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if !called {
+		t.Fatal("expected the wrapped handler to run when no policy matches the Origin")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want unset", got)
+	}
+}
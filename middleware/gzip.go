@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// gzippableTypes lists the Content-Type prefixes Gzip compresses. Already compressed formats
+// (images, video, archives) are skipped since compressing them again wastes CPU for no size
+// benefit.
+var gzippableTypes = []string{"text/", "application/json", "application/xml", "application/javascript"}
+
+// gzipWriterPools holds one sync.Pool of *gzip.Writer per compression level so each level's
+// writers can be reused across requests without reallocating their internal tables.
+var gzipWriterPools = map[int]*sync.Pool{}
+
+func gzipWriterPool(level int) *sync.Pool {
+	if p, ok := gzipWriterPools[level]; ok {
+		return p
+	}
+	p := &sync.Pool{
+		New: func() interface{} {
+			w, _ := gzip.NewWriterLevel(nil, level)
+			return w
+		},
+	}
+	gzipWriterPools[level] = p
+	return p
+}
+
+// Gzip returns a middleware that compresses the response body with the given compression level
+// (see compress/flate for the accepted range) when the client's Accept-Encoding header allows it.
+// It skips requests for content types listed in gzippableTypes's complement (already compressed
+// media) and responses smaller than minSize bytes, since compressing tiny payloads only adds
+// overhead.
+func Gzip(level int, minSize int) Middleware {
+	pool := gzipWriterPool(level)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			gw := &gzipWriter{ResponseWriter: w, pool: pool, minSize: minSize}
+			defer gw.Close()
+			h.ServeHTTP(gw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the client's Accept-Encoding header includes "gzip".
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(enc) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipWriter lazily wraps an http.ResponseWriter with a pooled *gzip.Writer the first time
+// enough bytes have been written to clear minSize, and otherwise passes writes through
+// uncompressed.
+type gzipWriter struct {
+	http.ResponseWriter
+	pool    *sync.Pool
+	minSize int
+	gz      *gzip.Writer
+	buf     []byte
+	status  int
+}
+
+// WriteHeader defers sending status until Write (or Close) has decided whether to compress the
+// response, so the Content-Encoding/Content-Length headers startGzip sets still land before the
+// status line is committed - unlike statusWriter in log.go, which records the status to read it
+// back out but passes it on to the wrapped ResponseWriter at once.
+func (w *gzipWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// flushHeader sends the deferred status code, if any, to the underlying ResponseWriter.
+func (w *gzipWriter) flushHeader() {
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+		w.status = 0
+	}
+}
+
+// Write buffers up to minSize bytes before deciding whether to compress, so that small responses
+// are not penalized with gzip's fixed overhead.
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	if !isCompressible(w.Header().Get("Content-Type")) {
+		w.flushHeader()
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.minSize {
+		return len(p), nil
+	}
+	w.startGzip()
+	n, err := w.gz.Write(w.buf)
+	w.buf = nil
+	if n > len(p) {
+		n = len(p)
+	}
+	return n, err
+}
+
+// startGzip switches the writer into compressed mode, setting the headers gzip requires before
+// flushing the (possibly deferred) status code.
+func (w *gzipWriter) startGzip() {
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.flushHeader()
+	gz := w.pool.Get().(*gzip.Writer)
+	gz.Reset(w.ResponseWriter)
+	w.gz = gz
+}
+
+// Close flushes any buffered, uncompressed bytes and releases the pooled *gzip.Writer.
+func (w *gzipWriter) Close() error {
+	if w.gz == nil {
+		w.flushHeader()
+		if len(w.buf) > 0 {
+			w.ResponseWriter.Write(w.buf)
+		}
+		return nil
+	}
+	err := w.gz.Close()
+	w.gz.Reset(nil)
+	w.pool.Put(w.gz)
+	return err
+}
+
+// isCompressible reports whether contentType matches one of gzippableTypes.
+func isCompressible(contentType string) bool {
+	for _, t := range gzippableTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return contentType == ""
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzip_CompressesAndPreservesStatus(t *testing.T) {
+	body := strings.Repeat("hello goa ", 200) // well over any reasonable minSize
+	h := Gzip(gzip.BestSpeed, 100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(body))
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("response body is not valid gzip: %s", err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("failed to decompress response body: %s", err)
+	}
+	if string(got) != body {
+		t.Fatalf("decompressed body = %q, want %q", got, body)
+	}
+}
+
+func TestGzip_SkipsSmallResponses(t *testing.T) {
+	h := Gzip(gzip.BestSpeed, 1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("tiny"))
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want unset for a response under minSize", got)
+	}
+	if got := w.Body.String(); got != "tiny" {
+		t.Fatalf("body = %q, want %q", got, "tiny")
+	}
+}
+
+func TestGzip_SkipsWithoutAcceptEncoding(t *testing.T) {
+	h := Gzip(gzip.BestSpeed, 1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want unset without Accept-Encoding: gzip", got)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("hello")
+	if w.Body.String() != buf.String() {
+		t.Fatalf("body = %q, want %q", w.Body.String(), buf.String())
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// LogRequest returns a middleware that logs the method, path, response status and duration of
+// every request using the standard library logger.
+func LogRequest() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			rw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(rw, r)
+			log.Printf("%s %s %d %s", r.Method, r.URL.Path, rw.status, time.Since(started))
+		})
+	}
+}
+
+// statusWriter records the status code written to an http.ResponseWriter so it can be reported
+// after the wrapped handler returns.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records status before delegating to the wrapped ResponseWriter.
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
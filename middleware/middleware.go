@@ -0,0 +1,49 @@
+// Package middleware provides the built-in middleware that may be referenced by name from the
+// Use DSL (see design/dsl) as well as the Middleware function type generated handlers are wired
+// through.
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps a http.Handler to add behavior before and/or after the wrapped handler runs,
+// analogous to the negroni/go-restful filter chains.
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes the given middleware into a single Middleware that runs them in order before
+// invoking the final handler, i.e. Chain(a, b)(h) runs a, then b, then h.
+func Chain(middleware ...Middleware) Middleware {
+	return func(h http.Handler) http.Handler {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			h = middleware[i](h)
+		}
+		return h
+	}
+}
+
+// defaultTimeout and defaultGzipMinSize are the configurations Registry pre-registers "Timeout" and
+// "Gzip" under, since Use only ever references a middleware by name and so has no way to pass
+// Timeout/Gzip their constructor arguments. Applications that need a different deadline,
+// compression level or size threshold should Register their own configured instance under a
+// different name and reference that name from Use instead.
+const defaultTimeout = 30 * time.Second
+const defaultGzipMinSize = 1400
+
+// Registry maps the names usable from the Use DSL to their Middleware implementation. The
+// built-in middleware (RequestID, LogRequest, Recover, Timeout, Gzip) are pre-registered, the
+// latter two under the defaults above; applications may add their own via Register.
+var Registry = map[string]Middleware{
+	"RequestID":  RequestID(),
+	"LogRequest": LogRequest(),
+	"Recover":    Recover(),
+	"Timeout":    Timeout(defaultTimeout),
+	"Gzip":       Gzip(gzip.DefaultCompression, defaultGzipMinSize),
+}
+
+// Register associates name with m so it can be referenced from the Use DSL.
+func Register(name string, m Middleware) {
+	Registry[name] = m
+}
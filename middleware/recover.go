@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recover returns a middleware that recovers from panics in the wrapped handler, logs the panic
+// value and stack trace, and responds with a 500 Internal Server Error instead of crashing the
+// process.
+func Recover() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if v := recover(); v != nil {
+					log.Printf("panic: %v\n%s", v, debug.Stack())
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+			h.ServeHTTP(w, r)
+		})
+	}
+}
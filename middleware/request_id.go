@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+)
+
+// requestIDKey is the context key under which RequestID stores the generated request ID.
+type requestIDKey struct{}
+
+// RequestID returns a middleware that assigns a unique ID to each request, reusing the
+// X-Request-Id header supplied by the client (e.g. a load balancer) when present, and exposes it
+// to downstream handlers via the request context.
+func RequestID() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get("X-Request-Id")
+			if id == "" {
+				id = newRequestID()
+			}
+			w.Header().Set("X-Request-Id", id)
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ContextRequestID extracts the request ID set by RequestID from ctx, returning "" if none was
+// set.
+func ContextRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID generates a random 32 character hexadecimal request identifier.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	const hex = "0123456789abcdef"
+	id := make([]byte, 32)
+	for i, c := range b {
+		id[i*2] = hex[c>>4]
+		id[i*2+1] = hex[c&0xf]
+	}
+	return string(id)
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Timeout returns a middleware that cancels the request context after d elapses, so that
+// handlers checking r.Context().Err() can abort long running work. It responds with 503 Service
+// Unavailable if the wrapped handler has not written a response by the time the deadline expires.
+func Timeout(d time.Duration) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.TimeoutHandler(h, d, "")
+	}
+}
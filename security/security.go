@@ -0,0 +1,85 @@
+// Package security provides the runtime support for the Security DSL: the SecurityHandler
+// interface applications implement to validate credentials, and the middleware goagen generates
+// from a SecuritySchemeDefinition to extract those credentials and enforce required scopes.
+package security
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/raphael/goa/design"
+	"github.com/raphael/goa/middleware"
+)
+
+// SecurityHandler validates the credentials extracted from a request for a given security scheme
+// and returns the context carrying whatever principal/claims information downstream handlers
+// need. It returns an *InvalidCredentialsError if the credentials themselves are invalid or
+// expired, or any other error if they are valid but lack one of the required scopes.
+type SecurityHandler interface {
+	Validate(ctx context.Context, schemeName string, credentials string, scopes []string) (context.Context, error)
+}
+
+// InvalidCredentialsError is the error a SecurityHandler returns from Validate to indicate that
+// the credentials themselves are invalid or expired, as opposed to being valid but missing a
+// required scope. New rejects it with 401; any other error from Validate is treated as an
+// authorization (scope) failure and rejected with 403.
+type InvalidCredentialsError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvalidCredentialsError) Error() string { return e.Reason }
+
+// New returns a middleware that extracts credentials from the request according to scheme's
+// location (header, query or cookie), rejects the request with 401 if they are missing or
+// SecurityHandler reports them invalid via *InvalidCredentialsError, with 403 if SecurityHandler
+// reports any other error (i.e. the credentials don't satisfy scopes), and otherwise calls the
+// wrapped handler with the context SecurityHandler returned.
+func New(scheme *design.SecuritySchemeDefinition, scopes []string, h SecurityHandler) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creds := extractCredentials(scheme, r)
+			if creds == "" {
+				http.Error(w, "missing credentials", http.StatusUnauthorized)
+				return
+			}
+			ctx, err := h.Validate(r.Context(), scheme.SchemeName, creds, scopes)
+			if err != nil {
+				if _, ok := err.(*InvalidCredentialsError); ok {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// extractCredentials reads the raw credentials value from the request location declared by
+// scheme, stripping the "Basic "/"Bearer " prefix HTTP authentication schemes conventionally use.
+func extractCredentials(scheme *design.SecuritySchemeDefinition, r *http.Request) string {
+	var raw string
+	switch scheme.In {
+	case "query":
+		raw = r.URL.Query().Get(scheme.Name)
+	case "cookie":
+		if c, err := r.Cookie(scheme.Name); err == nil {
+			raw = c.Value
+		}
+	default:
+		name := scheme.Name
+		if name == "" {
+			name = "Authorization"
+		}
+		raw = r.Header.Get(name)
+	}
+	if scheme.Kind == design.BasicAuthSecurityKind || scheme.Kind == design.JWTSecurityKind {
+		if i := strings.IndexByte(raw, ' '); i != -1 {
+			raw = raw[i+1:]
+		}
+	}
+	return raw
+}
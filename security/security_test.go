@@ -0,0 +1,93 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raphael/goa/design"
+)
+
+type credentialsKey struct{}
+
+type fakeHandler struct {
+	err error
+}
+
+func (h *fakeHandler) Validate(ctx context.Context, schemeName, credentials string, scopes []string) (context.Context, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return context.WithValue(ctx, credentialsKey{}, credentials), nil
+}
+
+func TestNew_MissingCredentialsReturns401(t *testing.T) {
+	scheme := &design.SecuritySchemeDefinition{In: "header", Name: "Authorization"}
+	h := New(scheme, nil, &fakeHandler{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run without credentials")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestNew_InvalidCredentialsReturns401(t *testing.T) {
+	scheme := &design.SecuritySchemeDefinition{In: "header", Name: "Authorization"}
+	h := New(scheme, nil, &fakeHandler{err: &InvalidCredentialsError{Reason: "expired token"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run with invalid credentials")
+		}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer abc")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestNew_InsufficientScopeReturns403(t *testing.T) {
+	scheme := &design.SecuritySchemeDefinition{In: "header", Name: "Authorization"}
+	h := New(scheme, []string{"api:write"}, &fakeHandler{err: context.DeadlineExceeded})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run without the required scope")
+		}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer abc")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestNew_ValidCredentialsCallNextWithContext(t *testing.T) {
+	scheme := &design.SecuritySchemeDefinition{Kind: design.JWTSecurityKind, In: "header", Name: "Authorization"}
+	var got interface{}
+	h := New(scheme, nil, &fakeHandler{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Context().Value(credentialsKey{})
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer abc")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got != "abc" {
+		t.Fatalf("credentials in context = %v, want %q", got, "abc")
+	}
+}
+
+func TestExtractCredentials_Cookie(t *testing.T) {
+	scheme := &design.SecuritySchemeDefinition{In: "cookie", Name: "session"}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "sesstoken"})
+	if got := extractCredentials(scheme, r); got != "sesstoken" {
+		t.Fatalf("extractCredentials() = %q, want %q", got, "sesstoken")
+	}
+}
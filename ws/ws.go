@@ -0,0 +1,88 @@
+// Package ws provides the runtime support for the Stream DSL (see design/dsl): the Upgrade
+// function goagen calls from a generated WS action handler to switch the connection to a
+// WebSocket per the design's StreamDefinition, and the Conn type applications use to exchange
+// JSON messages over it.
+package ws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/raphael/goa/design"
+)
+
+// upgrader is shared across connections; its buffer sizes are generic defaults since
+// StreamDefinition does not expose per-action buffer tuning.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// Conn wraps a *websocket.Conn configured from a StreamDefinition's keep-alive and message size
+// settings, exposing Send/Recv methods that marshal/unmarshal messages as JSON - the same wire
+// format the generated HTTP handlers use for regular request/response bodies.
+type Conn struct {
+	ws     *websocket.Conn
+	stream *design.StreamDefinition
+}
+
+// Upgrade switches r/w to a WebSocket connection configured per stream, installing a read size
+// limit and ping/pong keep-alive when stream declares them. The caller is responsible for closing
+// the returned Conn once done.
+func Upgrade(w http.ResponseWriter, r *http.Request, stream *design.StreamDefinition) (*Conn, error) {
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	if stream.MaxMessageSize > 0 {
+		c.SetReadLimit(stream.MaxMessageSize)
+	}
+	conn := &Conn{ws: c, stream: stream}
+	if stream.PingInterval > 0 {
+		conn.startPing()
+	}
+	return conn, nil
+}
+
+// Send marshals v as JSON and writes it as a single WebSocket text message, applying the stream's
+// WriteTimeout if one is set.
+func (c *Conn) Send(v interface{}) error {
+	if c.stream.WriteTimeout > 0 {
+		if err := c.ws.SetWriteDeadline(time.Now().Add(c.stream.WriteTimeout)); err != nil {
+			return err
+		}
+	}
+	return c.ws.WriteJSON(v)
+}
+
+// Recv blocks until the next WebSocket message arrives and unmarshals it as JSON into v, which
+// must be a pointer.
+func (c *Conn) Recv(v interface{}) error {
+	return c.ws.ReadJSON(v)
+}
+
+// Close closes the underlying network connection without sending a close control frame.
+func (c *Conn) Close() error {
+	return c.ws.Close()
+}
+
+// startPing pings the client every PingInterval until the connection closes, resetting the read
+// deadline on every pong so Recv only fails once the client truly stops responding rather than on
+// the first missed beat.
+func (c *Conn) startPing() {
+	interval := c.stream.PingInterval
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(2 * interval))
+	})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			deadline := time.Now().Add(interval)
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		}
+	}()
+}
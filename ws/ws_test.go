@@ -0,0 +1,97 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/raphael/goa/design"
+)
+
+func TestConn_SendRecv(t *testing.T) {
+	stream := &design.StreamDefinition{MaxMessageSize: 1 << 10}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, stream)
+		if err != nil {
+			t.Errorf("Upgrade failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		var msg map[string]string
+		if err := conn.Recv(&msg); err != nil {
+			t.Errorf("Recv failed: %s", err)
+			return
+		}
+		if err := conn.Send(msg); err != nil {
+			t.Errorf("Send failed: %s", err)
+		}
+	}))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %s", err)
+	}
+	defer c.Close()
+
+	if err := c.WriteJSON(map[string]string{"hello": "goa"}); err != nil {
+		t.Fatalf("WriteJSON failed: %s", err)
+	}
+	var got map[string]string
+	if err := c.ReadJSON(&got); err != nil {
+		t.Fatalf("ReadJSON failed: %s", err)
+	}
+	if got["hello"] != "goa" {
+		t.Fatalf("got %v, want {hello: goa}", got)
+	}
+}
+
+func TestConn_PingKeepsConnectionAlive(t *testing.T) {
+	stream := &design.StreamDefinition{PingInterval: 10 * time.Millisecond}
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, stream)
+		if err != nil {
+			t.Errorf("Upgrade failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		<-done
+	}))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %s", err)
+	}
+	defer c.Close()
+
+	pinged := make(chan struct{}, 1)
+	c.SetPingHandler(func(string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return c.WriteControl(websocket.PongMessage, nil, time.Now().Add(time.Second))
+	})
+	c.SetReadDeadline(time.Now().Add(time.Second))
+	go func() {
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a ping within 1s")
+	}
+	close(done)
+}